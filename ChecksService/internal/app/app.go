@@ -6,13 +6,16 @@ import (
 	"config"
 	"conn"
 	"context"
+	e "errorspomka"
 	"fmt"
 	log "logger"
 	"migrations"
 	"postgres"
 	"protobuf/checks"
 	"server"
+	"time"
 	"utils/hasher"
+	"utils/signer"
 
 	"google.golang.org/grpc"
 )
@@ -49,6 +52,11 @@ func Run() {
 	}
 	logger.WithField("MSG", fmt.Sprintf("Succecs connect to gRPC server (service Users) on %s:%s", cfg.Conn.ConfigServiceUsers.Host, cfg.Conn.ConfigServiceUsers.Port)).Debug("SETUP APP")
 
+	// Secret of links must not be salt of hashes, because salt is stored in hashes
+	if cfg.Storage.ChecksLinkSecret == "" || cfg.Storage.ChecksLinkSecret == cfg.Storage.HashSalt {
+		logger.WithField("ERROR", e.ErrMissingEnviroment).Fatal("SETUP APP")
+	}
+
 	// Creating hasher
 	hasher := hasher.NewHasher(cfg.Storage.HashSalt)
 
@@ -57,15 +65,47 @@ func Run() {
 
 	// Register service promos
 	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(logger.LoggingUnaryInterceptor))
-	service := service.NewServiceChecks(repo, pool, clientServices)
+	service := service.NewServiceChecks(repo, pool, signer.NewSigner(cfg.Storage.ChecksLinkSecret),
+		service.Config{
+			LinkURL: cfg.Storage.ChecksLinkURL,
+			Quotas: service.Quotas{
+				MaxOpen:        cfg.Storage.ChecksMaxOpen,
+				MaxLockedValue: cfg.Storage.ChecksMaxLockedValue,
+				MinAmount:      cfg.Storage.ChecksMinAmount,
+				MaxAmount:      cfg.Storage.ChecksMaxAmount,
+				MaxPerDay:      cfg.Storage.ChecksMaxPerDay,
+			},
+		}, clientServices)
 	checks.RegisterChecksServer(grpcSrv, service)
 
+	// Run refunding of expired checks in background
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(time.Duration(cfg.Storage.ChecksSweepIntervalS) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-sweepCtx.Done():
+				return
+			case <-ticker.C:
+				if err := service.RefundExpiredChecks(sweepCtx); err != nil {
+					logger.WithField("ERROR", err).Error("REFUND EXPIRED CHECKS")
+				}
+			}
+		}
+	}()
+	logger.WithField("MSG", fmt.Sprintf("Running refunding of expired checks every %ds", cfg.Storage.ChecksSweepIntervalS)).Debug("SETUP APP")
+
 	// Run server
 	logger.WithField("MSG", fmt.Sprintf("Running server on %s:%s", cfg.Server.Network, cfg.Server.Port)).Debug("SETUP APP")
 	server := server.NewServer(grpcSrv)
 
 	// defer all stoping
 	defer func() {
+		stopSweep()
+		logger.WithField("MSG", "Stoping refunding of expired checks").Debug("CLOSING APP")
+
 		pool.Close()
 		logger.WithField("MSG", fmt.Sprintf("Closing connect to postgres://%s:%s@%s:%s/%s",
 			cfg.DB.User, "<PASSWORD>", cfg.DB.Host, cfg.DB.Port, cfg.DB.Database)).Debug("CLOSING APP")
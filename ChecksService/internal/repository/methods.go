@@ -2,35 +2,84 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"crypto/subtle"
 	"errors"
 	e "errorspomka"
+	"fmt"
 	"postgres"
 	"protobuf/checks"
+	"protobuf/common"
 	"protobuf/users"
 	"time"
+	"utils/page"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (r *Repository) CreateCheck(ctx context.Context, db postgres.DB, in *checks.CheckCreate) (*checks.Check, error) {
-	var check = new(checks.Check)
-	var createdAt = new(time.Time)
+
+	// Check args valid, because Exec() send panic if have error
+	if !(in.Value != nil && 0 < in.Value.Currency && in.Value.Currency <= 2 && 0 < in.Value.Amount && in.Value.Amount <= maxAmount &&
+		0 <= in.Activations && in.Activations <= maxActivations) {
+		return nil, e.ErrCheckBadArgs
+	}
+
+	// Every currency of check once, value and bundle can't have same currency
+	var currencies = map[common.Currency]bool{in.Value.Currency: true}
+	for _, v := range in.Bundle {
+		if v == nil || v.Currency <= 0 || v.Currency > 2 || v.Amount <= 0 || v.Amount > maxAmount || currencies[v.Currency] {
+			return nil, e.ErrCheckBundle
+		}
+		currencies[v.Currency] = true
+	}
+
+	// Check can't be expired before creating
+	if in.ExpAt != nil && in.ExpAt.AsTime().Before(time.Now()) {
+		return nil, e.ErrExpAt
+	}
+
 	var key = uuid.New().String()
+	var expAt, password *string
+	var recipient *int64
+	if in.ExpAt != nil {
+		t := in.ExpAt.AsTime().Format("2006-01-02 15:04:05")
+		expAt = &t
+	}
+	if in.GetPassword() != "" {
+		p := r.h.Hash(in.GetPassword())
+		password = &p
+	}
+	if in.Recipient != nil {
+		recipient = &in.Recipient.Id
+	}
 
-	q := `INSERT INTO "Checks" ("CreatorId", "Key", "Currency", "Amount") 
-		  VALUES ($1, $2, $3, $4)
-		  RETURNING "Id", "CreatorId", "Key", "Currency", "Amount", "CreatedAt"`
+	// Check without activations can be used once
+	activations := max(in.Activations, 1)
 
-	if err := db.QueryRow(
-		ctx, q, in.Creator, r.h.Hash(key), in.Currency, in.Amount).
-		Scan(&check.Id, &check.Creator, nil, &check.Currency, &check.Amount, &createdAt); err != nil {
+	q := `INSERT INTO "Checks" ("CreatorId", "Key", "Currency", "Amount", "Activations", "ActivationsLeft", "ExpAt", "Password", "RecipientId")
+		  VALUES ($1, $2, $3, $4, $5, $5, $6, $7, $8)
+		  RETURNING *`
+
+	check, err := scanCheck(db.QueryRow(
+		ctx, q, in.Creator.GetId(), r.h.Hash(key), in.Value.Currency, in.Value.Amount, activations, expAt, password, recipient))
+	if err != nil {
 		return nil, errors.Join(e.ErrExecQuery, err)
 	}
 
-	check.CreatedAt = timestamppb.New(*createdAt)
+	// Add other currencies of check
+	for _, v := range in.Bundle {
+		q := `INSERT INTO "CheckBundles" ("CheckId", "Currency", "Amount")
+		      VALUES ($1, $2, $3)`
+
+		if _, err := db.Exec(ctx, q, check.Id, v.Currency, v.Amount); err != nil {
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
 	check.Key = key
+	check.Bundle = in.Bundle
 
 	return check, nil
 }
@@ -46,59 +95,586 @@ func (r *Repository) RemoveCheck(ctx context.Context, db postgres.DB, in *checks
 	return nil
 }
 
-func (r *Repository) GetUsersCheck(ctx context.Context, db postgres.DB, in *users.Id) (*checks.AllChecks, error) {
+// Get page of checks created by user, filtered by currency and amount
+func (r *Repository) GetUsersCheck(ctx context.Context, db postgres.DB, in *checks.ChecksFilter) (*checks.AllChecks, error) {
+	var allChecks = new(checks.AllChecks)
+
+	limit, err := page.Limit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := page.Decode(in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *string
+	var afterId int64
+	if cursor != nil {
+		t := cursor.Time.Format("2006-01-02 15:04:05.999999")
+		after, afterId = &t, cursor.Id
+	}
+
+	// Newest checks first by default
+	cmp, order := "<", "DESC"
+	if in.OldestFirst {
+		cmp, order = ">", "ASC"
+	}
+
+	q := fmt.Sprintf(`SELECT * FROM "Checks"
+	      WHERE "CreatorId"=$1
+		  AND ($2::SMALLINT IS NULL OR "Currency"=$2 OR EXISTS (
+		      SELECT 1 FROM "CheckBundles" WHERE "CheckId"="Id" AND "Currency"=$2))
+		  AND ($3::BIGINT IS NULL OR "Amount">=$3)
+		  AND ($4::BIGINT IS NULL OR "Amount"<=$4)
+		  AND ($5::TIMESTAMP IS NULL OR ("CreatedAt", "Id") %s ($5::TIMESTAMP, $6))
+		  ORDER BY "CreatedAt" %s, "Id" %s
+		  LIMIT $7`, cmp, order, order)
+
+	// One more row, for knowing next page exists or not
+	rows, err := db.Query(ctx, q, in.UserId, in.Currency, in.MinAmount, in.MaxAmount, after, afterId, limit+1)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		check, err := scanCheck(rows)
+		if err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		allChecks.Checks = append(allChecks.Checks, check)
+	}
+
+	if len(allChecks.Checks) > int(limit) {
+		allChecks.Checks = allChecks.Checks[:limit]
+		last := allChecks.Checks[limit-1]
+		allChecks.NextPageToken = page.Encode(page.Cursor{Time: last.CreatedAt.AsTime(), Id: last.Id})
+	}
+
+	if err := getCheckBundles(ctx, db, allChecks.Checks...); err != nil {
+		return nil, err
+	}
+
+	return allChecks, nil
+}
+
+func (r *Repository) GetChecksByRecipient(ctx context.Context, db postgres.DB, in *users.Id) (*checks.AllChecks, error) {
 	var allChecks = new(checks.AllChecks)
 
 	q := `SELECT * FROM "Checks"
-	      WHERE "CreatorId"=$1`
+	      WHERE "RecipientId"=$1`
 
 	rows, err := db.Query(ctx, q, in.Id)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return nil, nil
-		default:
-			return nil, errors.Join(e.ErrExecQuery, err)
-		}
+		return nil, errors.Join(e.ErrExecQuery, err)
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var check = new(checks.Check)
-		var createdAt = new(time.Time)
-
-		if err := rows.Scan(&check.Id, &check.Creator, &check.Key, &check.Currency, &check.Amount, &createdAt); err != nil {
+		check, err := scanCheck(rows)
+		if err != nil {
 			return nil, errors.Join(e.ErrIncorrectData, err)
 		}
 
-		check.CreatedAt = timestamppb.New(*createdAt)
 		allChecks.Checks = append(allChecks.Checks, check)
 	}
 
+	if err := getCheckBundles(ctx, db, allChecks.Checks...); err != nil {
+		return nil, err
+	}
+
 	return allChecks, nil
 }
 
 func (r *Repository) GetCheckByKey(ctx context.Context, db postgres.DB, key string) (*checks.Check, error) {
-	var check = new(checks.Check)
-	var createdAt = new(time.Time)
 	key = r.h.Hash(key)
 
 	q := `SELECT * FROM "Checks"
 	      WHERE "Key"=$1`
 
-	err := db.QueryRow(
-		ctx, q, key).
-		Scan(&check.Id, &check.Creator, &check.Key, &check.Currency, &check.Amount, createdAt)
+	check, err := scanCheck(db.QueryRow(ctx, q, key))
+	if err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, errors.Join(e.ErrCheckNotValid, err)
+		default:
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	if err := getCheckBundles(ctx, db, check); err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
+
+func (r *Repository) GetCheckById(ctx context.Context, db postgres.DB, in *checks.CheckId) (*checks.Check, error) {
+	q := `SELECT * FROM "Checks"
+	      WHERE "Id"=$1`
 
+	check, err := scanCheck(db.QueryRow(ctx, q, in.Id))
 	if err != nil {
 		switch err {
-		case sql.ErrNoRows:
+		case pgx.ErrNoRows:
 			return nil, errors.Join(e.ErrCheckNotValid, err)
 		default:
 			return nil, errors.Join(e.ErrExecQuery, err)
 		}
 	}
 
+	if err := getCheckBundles(ctx, db, check); err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
+
+// Get ids of checks with expiration time in the past
+func (r *Repository) GetExpiredChecks(ctx context.Context, db postgres.DB) ([]*checks.CheckId, error) {
+	var ids []*checks.CheckId
+
+	q := `SELECT "Id" FROM "Checks"
+	      WHERE "Status" = 0 AND "ExpAt" IS NOT NULL AND "ExpAt" <= $1`
+
+	rows, err := db.Query(ctx, q, time.Now().UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id = new(checks.CheckId)
+		if err := rows.Scan(&id.Id); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// Get activations of check from table UserToCheck, oldest first
+func (r *Repository) GetCheckActivations(ctx context.Context, db postgres.DB, in *checks.CheckId) ([]*checks.CheckActivation, error) {
+	var activations []*checks.CheckActivation
+
+	q := `SELECT "UserId", "ActivatedAt" FROM "UserToCheck"
+	      WHERE "CheckId"=$1
+		  ORDER BY "ActivatedAt"`
+
+	rows, err := db.Query(ctx, q, in.Id)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activation = &checks.CheckActivation{User: new(users.Id)}
+		var activatedAt = new(time.Time)
+
+		if err := rows.Scan(&activation.User.Id, activatedAt); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		activation.ActivatedAt = timestamppb.New(*activatedAt)
+		activations = append(activations, activation)
+	}
+
+	return activations, nil
+}
+
+// Lock creator till end of transaction, so parallel requests of creator count checks one by one
+func (r *Repository) LockCreator(ctx context.Context, db postgres.DB, user *users.Id) error {
+	q := `SELECT pg_advisory_xact_lock($1)`
+
+	if _, err := db.Exec(ctx, q, user.GetId()); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// Count active checks of creator
+func (r *Repository) CountOpenChecks(ctx context.Context, db postgres.DB, user *users.Id) (int64, error) {
+	var count int64
+
+	q := `SELECT COUNT(*) FROM "Checks"
+	      WHERE "CreatorId"=$1 AND "Status"=0`
+
+	if err := db.QueryRow(ctx, q, user.GetId()).Scan(&count); err != nil {
+		return 0, errors.Join(e.ErrExecQuery, err)
+	}
+
+	return count, nil
+}
+
+// Count checks created by creator since time
+func (r *Repository) CountChecksCreatedSince(ctx context.Context, db postgres.DB, user *users.Id, since time.Time) (int64, error) {
+	var count int64
+
+	q := `SELECT COUNT(*) FROM "Checks"
+	      WHERE "CreatorId"=$1 AND "CreatedAt">=$2`
+
+	if err := db.QueryRow(ctx, q, user.GetId(), since.UTC().Format("2006-01-02 15:04:05")).Scan(&count); err != nil {
+		return 0, errors.Join(e.ErrExecQuery, err)
+	}
+
+	return count, nil
+}
+
+// Get value of activations left in active checks of creator, one value for every currency
+func (r *Repository) GetLockedValue(ctx context.Context, db postgres.DB, user *users.Id) ([]*checks.Value, error) {
+	var values []*checks.Value
+
+	q := `SELECT "Currency", SUM("Amount")::BIGINT FROM (
+	          SELECT "Currency", "Amount"::BIGINT * "ActivationsLeft" AS "Amount" FROM "Checks"
+		      WHERE "CreatorId"=$1 AND "Status"=0
+		      UNION ALL
+		      SELECT b."Currency", b."Amount" * c."ActivationsLeft" FROM "CheckBundles" b
+		      JOIN "Checks" c ON c."Id"=b."CheckId"
+		      WHERE c."CreatorId"=$1 AND c."Status"=0
+		  ) AS "Locked"
+		  GROUP BY "Currency"`
+
+	rows, err := db.Query(ctx, q, user.GetId())
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var value = new(checks.Value)
+		if err := rows.Scan(&value.Currency, &value.Amount); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// Make active check cancelled (by user) or expired (user is nil). Return check with activations left before closing.
+func (r *Repository) CloseCheck(ctx context.Context, db postgres.DB, in *checks.CheckId, status checks.CheckStatus, user *users.Id) (*checks.Check, error) {
+	q := `UPDATE "Checks"
+	      SET "Status" = $2, "ClosedAt" = $3, "CancelledBy" = $4
+		  WHERE "Id" = $1 AND "Status" = 0
+		  RETURNING *`
+
+	var cancelledBy *int64
+	if user != nil {
+		cancelledBy = &user.Id
+	}
+
+	closedAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	check, err := scanCheck(db.QueryRow(ctx, q, in.Id, status, closedAt, cancelledBy))
+	if err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, e.ErrCheckNotActive
+		default:
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	if err := getCheckBundles(ctx, db, check); err != nil {
+		return nil, err
+	}
+
+	return check, nil
+}
+
+// Make check redeemed by user, who used last activation
+func (r *Repository) SetCheckRedeemed(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) error {
+	q := `UPDATE "Checks"
+	      SET "Status" = $2, "RedeemedBy" = $3, "RedeemedAt" = $4, "ClosedAt" = $4
+		  WHERE "Id" = $1`
+
+	redeemedAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	if _, err := db.Exec(ctx, q, in.Id, checks.CheckStatus_Redeemed, user.GetId(), redeemedAt); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// If user is creator of check, return true. If user is not creator, return false.
+func (r *Repository) UserIsCheckCreator(in *checks.Check, user *users.Id) (bool, error) {
+	if in.Creator.GetId() != user.GetId() {
+		return false, e.ErrCheckCancel
+	}
+
+	return true, nil
+}
+
+// If user is moderator, return true. If user is not moderator, return false.
+func (r *Repository) UserIsModerator(user *users.User) (bool, error) {
+	if user.GetRole() != users.Role_Moderator {
+		return false, e.ErrCheckCancel
+	}
+
+	return true, nil
+}
+
+// If user is blocked, return true. If user is not blocked, return false.
+func (r *Repository) UserIsBlocked(user *users.User) (bool, error) {
+	if user.GetRole() == users.Role_Blocked {
+		return true, e.ErrCheckUserBlocked
+	}
+
+	return false, nil
+}
+
+// If check is active, return true. If check redeemed, cancelled or expired, return false.
+func (r *Repository) CheckIsActive(in *checks.Check) (bool, error) {
+	if in.Status != checks.CheckStatus_Active {
+		return false, e.ErrCheckNotActive
+	}
+
+	return true, nil
+}
+
+// If check is expired, return true. If check not expired or without expiration, return false.
+func (r *Repository) CheckIsExpired(in *checks.Check) (bool, error) {
+	if in.ExpAt != nil && time.Now().After(in.ExpAt.AsTime()) {
+		return true, e.ErrCheckExpired
+	}
+
+	return false, nil
+}
+
+// If check without password or password is right, return true. Wrong password return false.
+func (r *Repository) CheckPasswordIsValid(ctx context.Context, db postgres.DB, in *checks.CheckId, password string) (bool, error) {
+	var hash *string
+
+	q := `SELECT "Password" FROM "Checks"
+	      WHERE "Id"=$1`
+
+	if err := db.QueryRow(ctx, q, in.Id).Scan(&hash); err != nil {
+		return false, errors.Join(e.ErrExecQuery, err)
+	}
+
+	if hash == nil {
+		return true, nil
+	}
+
+	if subtle.ConstantTimeCompare([]byte(*hash), []byte(r.h.Hash(password))) != 1 {
+		return false, e.ErrCheckWrongPassword
+	}
+
+	return true, nil
+}
+
+// Count attempt of password by user before comparing, after too many attempts lock check for user for a while.
+// Attempt counted in one statement, so parallel requests can't get more attempts. Locked check returns error.
+func (r *Repository) AddPasswordAttempt(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) error {
+	var lockedUntil *time.Time
+
+	// Lock is over, attempts start again
+	q := `INSERT INTO "CheckPasswordAttempts" AS a ("CheckId", "UserId", "Attempts")
+	      VALUES ($1, $2, 1)
+		  ON CONFLICT ("CheckId", "UserId") DO UPDATE
+		  SET "Attempts" = CASE WHEN a."LockedUntil" <= $3 THEN 1 ELSE a."Attempts"+1 END,
+		      "LockedUntil" = CASE
+			      WHEN a."LockedUntil" <= $3 THEN NULL
+			      WHEN a."LockedUntil" IS NULL AND a."Attempts"+1 > $4 THEN $5
+			      ELSE a."LockedUntil" END
+		  RETURNING "LockedUntil"`
+
+	now := time.Now().UTC()
+	lockUntil := now.Add(passwordLock).Format("2006-01-02 15:04:05")
+	if err := db.QueryRow(ctx, q, in.Id, user.GetId(), now.Format("2006-01-02 15:04:05"), passwordAttempts, lockUntil).Scan(&lockedUntil); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	// Too many attempts, dont even compare
+	if lockedUntil != nil {
+		return e.ErrCheckLocked
+	}
+
+	return nil
+}
+
+// Forget attempts of password by user after right password
+func (r *Repository) ResetPasswordAttempts(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) error {
+	q := `DELETE FROM "CheckPasswordAttempts"
+	      WHERE "CheckId" = $1 AND "UserId" = $2`
+
+	if _, err := db.Exec(ctx, q, in.Id, user.GetId()); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// If check addressed to user or to anyone, return true. If check addressed to other user, return false.
+func (r *Repository) CheckIsForUser(in *checks.Check, user *users.Id) (bool, error) {
+	if in.Recipient != nil && in.Recipient.Id != user.GetId() {
+		return false, e.ErrCheckForOtherUser
+	}
+
+	return true, nil
+}
+
+// Update table checks, decrement activations left of check. Return activations left after decrement,
+// update locks check, so parallel activations get different values.
+func (r *Repository) DecrementCheckActivations(ctx context.Context, db postgres.DB, in *checks.CheckId) (int32, error) {
+	var left int32
+
+	q := `UPDATE "Checks"
+	      SET "ActivationsLeft" = "ActivationsLeft"-1
+		  WHERE "Id" = $1 AND "Status" = 0 AND "ActivationsLeft" > 0
+		  RETURNING "ActivationsLeft"`
+
+	if err := db.QueryRow(ctx, q, in.Id).Scan(&left); err != nil {
+		switch err {
+
+		// Other user took last activation
+		case pgx.ErrNoRows:
+			return 0, e.ErrCheckNotInStock
+		default:
+			return 0, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	return left, nil
+}
+
+// Insert activation of check to table UserToCheck
+func (r *Repository) AddCheckActivationToHistory(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) error {
+	q := `INSERT INTO "UserToCheck" ("UserId", "CheckId", "ActivatedAt")
+	      VALUES ($1, $2, $3)`
+
+	actAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	if _, err := db.Exec(ctx, q, user.GetId(), in.Id, actAt); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// Delete activations of check from table UserToCheck
+func (r *Repository) DeleteCheckActivationsFromHistory(ctx context.Context, db postgres.DB, in *checks.CheckId) error {
+	q := `DELETE FROM "UserToCheck"
+	      WHERE "CheckId"=$1`
+
+	if _, err := db.Exec(ctx, q, in.Id); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// If check been activated by user, return true. If check not activated by user, return false.
+func (r *Repository) CheckIsAlreadyActivated(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) (bool, error) {
+	var activated = new(bool)
+
+	q := `SELECT EXISTS(
+	      SELECT * FROM "UserToCheck"
+		  WHERE "UserId" = $1 AND "CheckId" = $2
+		  )`
+
+	if err := db.QueryRow(ctx, q, user.GetId(), in.Id).Scan(&activated); err != nil {
+		return false, errors.Join(e.ErrExecQuery, err)
+	}
+
+	if *activated {
+		return true, e.ErrCheckAlreadyActivated
+	}
+
+	return false, nil
+}
+
+// Add other currencies to checks from table check bundles
+func getCheckBundles(ctx context.Context, db postgres.DB, in ...*checks.Check) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	var byId = make(map[int64]*checks.Check, len(in))
+	var ids = make([]int64, 0, len(in))
+	for _, check := range in {
+		byId[check.Id] = check
+		ids = append(ids, check.Id)
+	}
+
+	q := `SELECT "CheckId", "Currency", "Amount" FROM "CheckBundles"
+	      WHERE "CheckId" = ANY($1)
+		  ORDER BY "Currency"`
+
+	rows, err := db.Query(ctx, q, ids)
+	if err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var value = new(checks.Value)
+		if err := rows.Scan(&id, &value.Currency, &value.Amount); err != nil {
+			return errors.Join(e.ErrIncorrectData, err)
+		}
+
+		byId[id].Bundle = append(byId[id].Bundle, value)
+	}
+
+	return nil
+}
+
+// Scan row of table checks, order of columns same as in table
+func scanCheck(row pgx.Row) (*checks.Check, error) {
+	var check = &checks.Check{Value: new(checks.Value), Creator: new(users.Id)}
+	var createdAt = new(time.Time)
+	var expAt *time.Time
+	var password *string
+	var recipient, redeemedBy, cancelledBy *int64
+	var redeemedAt, closedAt *time.Time
+
+	if err := row.Scan(
+		&check.Id,
+		&check.Creator.Id,
+		&check.Key,
+		&check.Value.Currency,
+		&check.Value.Amount,
+		createdAt,
+		&check.Activations,
+		&check.ActivationsLeft,
+		&expAt,
+		&password,
+		&recipient,
+		&check.Status,
+		&redeemedBy,
+		&redeemedAt,
+		&closedAt,
+		&cancelledBy); err != nil {
+		return nil, err
+	}
+
+	if recipient != nil {
+		check.Recipient = &users.Id{Id: *recipient}
+	}
+	if redeemedBy != nil {
+		check.RedeemedBy = &users.Id{Id: *redeemedBy}
+	}
+	if redeemedAt != nil {
+		check.RedeemedAt = timestamppb.New(*redeemedAt)
+	}
+	if closedAt != nil {
+		check.ClosedAt = timestamppb.New(*closedAt)
+	}
+	if cancelledBy != nil {
+		check.CancelledBy = &users.Id{Id: *cancelledBy}
+	}
+
+	check.HasPassword = password != nil
+
 	check.CreatedAt = timestamppb.New(*createdAt)
+	if expAt != nil {
+		check.ExpAt = timestamppb.New(*expAt)
+	}
 	return check, nil
 }
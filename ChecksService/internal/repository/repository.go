@@ -1,6 +1,24 @@
 package repository
 
-import "utils/hasher"
+import (
+	"math"
+	"time"
+	"utils/hasher"
+)
+
+const (
+	// Amount of one activation for every currency, same as type of column
+	maxAmount = math.MaxInt32
+
+	// Activations of one check
+	maxActivations = 100_000
+
+	// Count of wrong passwords by user before check will be locked for user
+	passwordAttempts = 5
+
+	// Time of lock check for user after too many wrong passwords
+	passwordLock = 15 * time.Minute
+)
 
 type Repository struct {
 	h *hasher.Hasher
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"protobuf/checks"
+	"protobuf/common"
+	"protobuf/users"
+	"utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Return unused value of expired checks to creators and make checks expired
+func (s *ServiceChecks) RefundExpiredChecks(ctx context.Context) error {
+	var errs []error
+
+	// Get expired checks
+	ids, err := s.GetExpiredChecks(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	// Refund every check in own transaction, so one failed check doesn't block others
+	for _, id := range ids {
+		if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+			// Make check expired, row locked until end of transaction
+			check, err := s.CloseCheck(ctx, tx, id, checks.CheckStatus_Expired, nil)
+			if err != nil {
+				return err
+			}
+
+			// Send transactions to service users, creator gets every currency of activations left
+			if _, err := s.sendValues(
+				ctx, &users.TransactionRequest{
+					Receiver: &users.UserTransaction{UserId: check.Creator.Id},
+					Type:     common.TransactionType_DeleteCheck,
+				}, check, int64(check.ActivationsLeft),
+			); err != nil {
+				return err
+			}
+
+			return nil
+
+		}); errTx != nil {
+			errs = append(errs, errTx)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"errors"
+	"math"
 	"protobuf/checks"
 	"protobuf/common"
 	"protobuf/users"
 
 	"utils"
 
+	e "errorspomka"
+
 	"github.com/jackc/pgx/v5"
 )
 
@@ -18,23 +22,38 @@ func (s *ServiceChecks) Create(ctx context.Context, in *checks.CheckCreate) (che
 	// Run in transaction
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
+		// Blocked user can't create checks
+		user, err := s.UserService.GetUser(ctx, in.Creator)
+		if err != nil {
+			return errors.Join(e.ErrServiceUsers, err)
+		}
+		if b, err := s.UserIsBlocked(user); err != nil || b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Check quotas of creator
+		if err := s.checkQuotas(ctx, tx, in, 1); err != nil {
+			return err
+		}
+
 		// Create check
 		checkFailure.Check, err = s.CreateCheck(ctx, tx, in)
 		if err != nil {
 			return err
 		}
 
-		// Send transation to service users
-		if _, err := s.UserService.SendTransaction(
+		// Send transations to service users, creator pays every currency for all activations
+		check := checkFailure.Check
+		if failure, err := s.sendValues(
 			ctx, &users.TransactionRequest{
-				Sender: &users.UserTransaction{
-					UserId:   in.Creator,
-					Amount:   in.Amount,
-					Currency: in.Currency,
-				},
-				Type: common.TransactionType_CreateCheck,
-			},
+				Sender: &users.UserTransaction{UserId: check.Creator.Id},
+				Type:   common.TransactionType_CreateCheck,
+			}, check, int64(check.Activations),
 		); err != nil {
+			if failure != nil {
+				codeError = failure.Code
+			}
 			return err
 		}
 
@@ -43,10 +62,8 @@ func (s *ServiceChecks) Create(ctx context.Context, in *checks.CheckCreate) (che
 	}); errTx != nil {
 		return &checks.CheckFailure{
 			Failure: &common.Failure{
-				Code: codeError,
-				Details: map[string]string{
-					"ERROR": errTx.Error(),
-				},
+				Code:    codeError,
+				Details: failureDetails(errTx),
 			},
 		}, errTx
 	}
@@ -54,20 +71,123 @@ func (s *ServiceChecks) Create(ctx context.Context, in *checks.CheckCreate) (che
 	return checkFailure, nil
 }
 
-func (s *ServiceChecks) Remove(ctx context.Context, in *checks.CheckId) (*common.Response, error) {
+func (s *ServiceChecks) CreateBatch(ctx context.Context, in *checks.CheckCreateBatch) (allChecksFailure *checks.AllChecksFailure, err error) {
 	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	allChecksFailure = &checks.AllChecksFailure{Checks: new(checks.AllChecks)}
 
 	// Run in transaction
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
-		// Remove check
-		if err := s.RemoveCheck(ctx, tx, in); err != nil {
+		// Check args of batch
+		if in.Check == nil {
+			return e.ErrCheckBadArgs
+		}
+		if in.Count < 1 || in.Count > maxBatchSize {
+			return e.ErrCheckBatchSize
+		}
+
+		// Blocked user can't create checks
+		user, err := s.UserService.GetUser(ctx, in.Check.Creator)
+		if err != nil {
+			return errors.Join(e.ErrServiceUsers, err)
+		}
+		if b, err := s.UserIsBlocked(user); err != nil || b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Check quotas of creator for all checks of batch
+		if err := s.checkQuotas(ctx, tx, in.Check, int64(in.Count)); err != nil {
 			return err
 		}
 
-		// Send transaction to service users
-		if _, err := s.UserService.SendTransaction(
-			ctx, &users.TransactionRequest{Type: common.TransactionType_DeleteCheck}); err != nil {
+		// Create checks
+		var activations int64
+		for range in.Count {
+			check, err := s.CreateCheck(ctx, tx, in.Check)
+			if err != nil {
+				return err
+			}
+
+			// Sum of activations can't overflow, amount of every currency multiplied by it before sending
+			if activations > math.MaxInt64-int64(check.Activations) {
+				return e.ErrCheckValueOverflow
+			}
+			activations += int64(check.Activations)
+			allChecksFailure.Checks.Checks = append(allChecksFailure.Checks.Checks, check)
+		}
+
+		// Send transations to service users, creator pays every currency once for all checks
+		if failure, err := s.sendValues(
+			ctx, &users.TransactionRequest{
+				Sender: &users.UserTransaction{UserId: in.Check.Creator.GetId()},
+				Type:   common.TransactionType_CreateCheck,
+			}, allChecksFailure.Checks.Checks[0], activations,
+		); err != nil {
+			if failure != nil {
+				codeError = failure.Code
+			}
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &checks.AllChecksFailure{
+			Failure: &common.Failure{
+				Code:    codeError,
+				Details: failureDetails(errTx),
+			},
+		}, errTx
+	}
+
+	return allChecksFailure, nil
+}
+
+func (s *ServiceChecks) Remove(ctx context.Context, in *checks.CheckCancel) (*common.Response, error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	checkId := &checks.CheckId{Id: in.Id}
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get check
+		check, err := s.GetCheckById(ctx, tx, checkId)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Only creator of check or moderator can cancel check
+		if b, _ := s.UserIsCheckCreator(check, in.UserId); !b {
+
+			// Get info about user
+			user, err := s.UserService.GetUser(ctx, in.UserId)
+			if err != nil {
+				return errors.Join(e.ErrServiceUsers, err)
+			}
+
+			// Check moderator role
+			if b, err := s.UserIsModerator(user); err != nil || !b {
+				codeError = common.ErrorCode_UserBadRole
+				return err
+			}
+		}
+
+		// Make check cancelled
+		check, err = s.CloseCheck(ctx, tx, checkId, checks.CheckStatus_Cancelled, in.UserId)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Send transactions to service users, creator gets every currency of activations left
+		if _, err := s.sendValues(
+			ctx, &users.TransactionRequest{
+				Receiver: &users.UserTransaction{UserId: check.Creator.Id},
+				Type:     common.TransactionType_DeleteCheck,
+			}, check, int64(check.ActivationsLeft),
+		); err != nil {
 			return err
 		}
 
@@ -89,32 +209,106 @@ func (s *ServiceChecks) Remove(ctx context.Context, in *checks.CheckId) (*common
 
 func (s *ServiceChecks) Use(ctx context.Context, in *checks.CheckUse) (*common.Response, error) {
 	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	var key = in.Key
+
+	// Get key from signed payload, forged payload rejected without transaction and query to db
+	if in.GetPayload() != "" {
+		var err error
+		if key, err = s.keyFromPayload(in.GetPayload()); err != nil {
+			return &common.Response{
+				Failure: &common.Failure{
+					Code: common.ErrorCode_CheckNotValid,
+					Details: map[string]string{
+						"ERROR": err.Error(),
+					},
+				},
+			}, err
+		}
+	}
 
 	// Run in transaction
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
 		// Get check
-		check, err := s.GetCheckByKey(ctx, tx, in.Key)
+		check, err := s.GetCheckByKey(ctx, tx, key)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+		checkId := &checks.CheckId{Id: check.Id}
+
+		// Check is active or not
+		if b, err := s.CheckIsActive(check); err != nil || !b {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Check is expired or not
+		if b, err := s.CheckIsExpired(check); err != nil || b {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Check addressed to this user or not
+		if b, err := s.CheckIsForUser(check, in.UserId); err != nil || !b {
+			codeError = common.ErrorCode_Forbidden
+			return err
+		}
+
+		// Check password, if check created with it
+		if check.HasPassword {
+
+			// Count attempt before comparing and outside of transaction, because transaction will be rolled back
+			if err := s.AddPasswordAttempt(ctx, s.db, checkId, in.UserId); err != nil {
+				codeError = common.ErrorCode_CheckNotValid
+				return err
+			}
+
+			if b, err := s.CheckPasswordIsValid(ctx, tx, checkId, in.GetPassword()); err != nil || !b {
+				codeError = common.ErrorCode_CheckNotValid
+				return err
+			}
+
+			if err := s.ResetPasswordAttempts(ctx, tx, checkId, in.UserId); err != nil {
+				return err
+			}
+		}
+
+		// Check already activated by user or not
+		if b, err := s.CheckIsAlreadyActivated(ctx, tx, checkId, in.UserId); err != nil || b {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Decrement activations left of check
+		left, err := s.DecrementCheckActivations(ctx, tx, checkId)
 		if err != nil {
 			codeError = common.ErrorCode_CheckNotValid
 			return err
 		}
 
-		// Send transaction to service users
-		if _, err := s.UserService.SendTransaction(
+		// Add activation of check to history
+		if err := s.AddCheckActivationToHistory(ctx, tx, checkId, in.UserId); err != nil {
+			return err
+		}
+
+		// Send transactions to service users, user gets every currency of one activation
+		if _, err := s.sendValues(
 			ctx, &users.TransactionRequest{
-				Sender: &users.UserTransaction{
-					UserId:   in.UserId,
-					Amount:   check.Amount,
-					Currency: check.Currency,
-				},
-				Type: common.TransactionType_CreateCheck,
-			},
+				Receiver: &users.UserTransaction{UserId: in.UserId.GetId()},
+				Type:     common.TransactionType_UseCheck,
+			}, check, 1,
 		); err != nil {
 			return err
 		}
 
-		if err := s.RemoveCheck(ctx, tx, &checks.CheckId{Id: check.Id}); err != nil {
+		// Pass, if check still have activations after decrement
+		if left > 0 {
+			return nil
+		}
+
+		// Make check redeemed, when last activation used
+		if err := s.SetCheckRedeemed(ctx, tx, checkId, in.UserId); err != nil {
 			return err
 		}
 
@@ -134,7 +328,7 @@ func (s *ServiceChecks) Use(ctx context.Context, in *checks.CheckUse) (*common.R
 	return nil, nil
 }
 
-func (s *ServiceChecks) GetUserChecks(ctx context.Context, in *users.Id) (allChecksFailure *checks.AllChecksFailure, err error) {
+func (s *ServiceChecks) GetUserChecks(ctx context.Context, in *checks.ChecksFilter) (allChecksFailure *checks.AllChecksFailure, err error) {
 	var codeError common.ErrorCode = common.ErrorCode_Forbidden
 	allChecksFailure = new(checks.AllChecksFailure)
 
@@ -142,7 +336,35 @@ func (s *ServiceChecks) GetUserChecks(ctx context.Context, in *users.Id) (allChe
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
 		// Get checks user
-		allChecksFailure.AllChecks, err = s.GetUsersCheck(ctx, tx, in)
+		allChecksFailure.Checks, err = s.GetUsersCheck(ctx, tx, in)
+		if err != nil {
+			return err
+		}
+		return nil
+
+	}); errTx != nil {
+		return &checks.AllChecksFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			},
+		}, errTx
+	}
+
+	return allChecksFailure, nil
+}
+
+func (s *ServiceChecks) GetChecksToUser(ctx context.Context, in *users.Id) (allChecksFailure *checks.AllChecksFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	allChecksFailure = new(checks.AllChecksFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get checks addressed to user
+		allChecksFailure.Checks, err = s.GetChecksByRecipient(ctx, tx, in)
 		if err != nil {
 			return err
 		}
@@ -161,3 +383,154 @@ func (s *ServiceChecks) GetUserChecks(ctx context.Context, in *users.Id) (allChe
 
 	return allChecksFailure, nil
 }
+
+func (s *ServiceChecks) GetLifecycle(ctx context.Context, in *checks.CheckLifecycleIn) (lifecycleFailure *checks.CheckLifecycleFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	lifecycleFailure = &checks.CheckLifecycleFailure{Lifecycle: new(checks.CheckLifecycle)}
+	checkId := &checks.CheckId{Id: in.Id}
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get check
+		check, err := s.GetCheckById(ctx, tx, checkId)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Only creator of check or moderator can see who redeemed check
+		if b, _ := s.UserIsCheckCreator(check, in.UserId); !b {
+
+			// Get info about user
+			user, err := s.UserService.GetUser(ctx, in.UserId)
+			if err != nil {
+				return errors.Join(e.ErrServiceUsers, err)
+			}
+
+			// Check moderator role
+			if b, _ := s.UserIsModerator(user); !b {
+				codeError = common.ErrorCode_UserBadRole
+				return e.ErrCheckLifecycle
+			}
+		}
+		lifecycleFailure.Lifecycle.Check = check
+
+		// Get activations of check
+		lifecycleFailure.Lifecycle.Activations, err = s.GetCheckActivations(ctx, tx, checkId)
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &checks.CheckLifecycleFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			},
+		}, errTx
+	}
+
+	return lifecycleFailure, nil
+}
+
+func (s *ServiceChecks) Preview(ctx context.Context, in *checks.CheckKey) (previewFailure *checks.CheckPreviewFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	previewFailure = new(checks.CheckPreviewFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get check
+		check, err := s.GetCheckByKey(ctx, tx, in.Key)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Only public information, without id and key of check
+		previewFailure.Preview = &checks.CheckPreview{
+			Value:           check.Value,
+			Bundle:          check.Bundle,
+			Creator:         check.Creator,
+			IsValid:         true,
+			ActivationsLeft: check.ActivationsLeft,
+			HasPassword:     check.HasPassword,
+			ExpAt:           check.ExpAt,
+			Recipient:       check.Recipient,
+		}
+
+		// Check is active and not expired
+		if b, err := s.CheckIsActive(check); err != nil || !b {
+			reason := err.Error()
+			previewFailure.Preview.IsValid, previewFailure.Preview.Reason = false, &reason
+			return nil
+		}
+		if b, err := s.CheckIsExpired(check); err != nil || b {
+			reason := err.Error()
+			previewFailure.Preview.IsValid, previewFailure.Preview.Reason = false, &reason
+			return nil
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &checks.CheckPreviewFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			},
+		}, errTx
+	}
+
+	return previewFailure, nil
+}
+
+func (s *ServiceChecks) Share(ctx context.Context, in *checks.CheckKey) (shareFailure *checks.CheckShareFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	shareFailure = new(checks.CheckShareFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get check
+		check, err := s.GetCheckByKey(ctx, tx, in.Key)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Check is active or not
+		if b, err := s.CheckIsActive(check); err != nil || !b {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		// Sign key of check, make link and QR code
+		shareFailure.Share, err = s.share(in.Key)
+		if err != nil {
+			codeError = common.ErrorCode_CheckNotValid
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &checks.CheckShareFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			},
+		}, errTx
+	}
+
+	return shareFailure, nil
+}
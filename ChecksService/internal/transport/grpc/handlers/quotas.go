@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"postgres"
+	"protobuf/checks"
+	"protobuf/common"
+	"strconv"
+	"time"
+
+	e "errorspomka"
+)
+
+// Quotas of checks for one user, 0 is no limit
+type Quotas struct {
+	MaxOpen        int   // Count of active checks
+	MaxLockedValue int64 // Value of activations left in active checks, for every currency
+	MinAmount      int64 // Amount of one activation, for every currency
+	MaxAmount      int64
+	MaxPerDay      int // Count of checks created since start of day (UTC)
+}
+
+// Error of exceeded quota, failure of handler gets name of quota and its limit in details
+type quotaError struct {
+	quota string
+	limit int64
+}
+
+func (q *quotaError) Error() string {
+	return fmt.Sprintf("%s: %s is %d", e.ErrCheckQuota, q.quota, q.limit)
+}
+
+func (q *quotaError) Unwrap() error {
+	return e.ErrCheckQuota
+}
+
+// Details of failure. If quota exceeded, name of quota and its limit added.
+func failureDetails(err error) map[string]string {
+	details := map[string]string{
+		"ERROR": err.Error(),
+	}
+
+	var quota *quotaError
+	if errors.As(err, &quota) {
+		details["QUOTA"] = quota.quota
+		details["LIMIT"] = strconv.FormatInt(quota.limit, 10)
+	}
+
+	return details
+}
+
+// Check creator doesn't exceed quotas after creating count checks.
+// Creator locked till end of transaction, so parallel requests can't pass quotas together.
+func (s *ServiceChecks) checkQuotas(ctx context.Context, db postgres.DB, in *checks.CheckCreate, count int64) error {
+	quotas := s.cfg.Quotas
+
+	if in.Value == nil {
+		return e.ErrCheckBadArgs
+	}
+	values := append([]*checks.Value{in.Value}, in.Bundle...)
+
+	// Amount of one activation
+	for _, value := range values {
+		if quotas.MinAmount > 0 && value.GetAmount() < quotas.MinAmount {
+			return &quotaError{"min amount", quotas.MinAmount}
+		}
+		if quotas.MaxAmount > 0 && value.GetAmount() > quotas.MaxAmount {
+			return &quotaError{"max amount", quotas.MaxAmount}
+		}
+	}
+
+	// Checks of creator are counted after parallel requests of creator finished
+	if err := s.LockCreator(ctx, db, in.Creator); err != nil {
+		return err
+	}
+
+	// Count of active checks
+	if quotas.MaxOpen > 0 {
+		open, err := s.CountOpenChecks(ctx, db, in.Creator)
+		if err != nil {
+			return err
+		}
+		if open+count > int64(quotas.MaxOpen) {
+			return &quotaError{"max open checks", int64(quotas.MaxOpen)}
+		}
+	}
+
+	// Count of checks created today
+	if quotas.MaxPerDay > 0 {
+		created, err := s.CountChecksCreatedSince(ctx, db, in.Creator, time.Now().UTC().Truncate(24*time.Hour))
+		if err != nil {
+			return err
+		}
+		if created+count > int64(quotas.MaxPerDay) {
+			return &quotaError{"max checks per day", int64(quotas.MaxPerDay)}
+		}
+	}
+
+	// Value locked in active checks, for every currency
+	if quotas.MaxLockedValue > 0 {
+		lockedValues, err := s.GetLockedValue(ctx, db, in.Creator)
+		if err != nil {
+			return err
+		}
+
+		locked := make(map[common.Currency]int64, len(lockedValues))
+		for _, value := range lockedValues {
+			locked[value.Currency] = value.Amount
+		}
+
+		activations, err := multiply(int64(max(in.Activations, 1)), count)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			total, err := multiply(value.GetAmount(), activations)
+			if err != nil {
+				return err
+			}
+
+			// Compared without sum, so sum can't overflow
+			if total > quotas.MaxLockedValue-locked[value.GetCurrency()] {
+				return &quotaError{"max locked value", quotas.MaxLockedValue}
+			}
+		}
+	}
+
+	return nil
+}
@@ -5,13 +5,19 @@ import (
 	"postgres"
 	"protobuf/checks"
 	"protobuf/users"
+	"time"
+	"utils/signer"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"google.golang.org/grpc"
 )
 
+// Max count of checks created by one CreateBatch
+const maxBatchSize = 100
+
 type Config struct {
-	WarnsBeforeBan int
+	LinkURL string // Prefix of deep link, payload of check appended to it
+	Quotas  Quotas
 }
 
 type UserService interface {
@@ -21,18 +27,45 @@ type UserService interface {
 
 type ServiceChecks struct {
 	RepositoryChecks
-	db *pgxpool.Pool
+	db     *pgxpool.Pool
+	signer *signer.Signer
+	cfg    Config
 	UserService
 	checks.UnimplementedChecksServer
 }
 
 type RepositoryChecks interface {
 	CreateCheck(ctx context.Context, db postgres.DB, in *checks.CheckCreate) (out *checks.Check, err error)
-	RemoveCheck(ctx context.Context, db postgres.DB, in *checks.CheckId) (err error)
-	GetUsersCheck(ctx context.Context, db postgres.DB, in *users.Id) (out *checks.AllChecks, err error)
+	GetUsersCheck(ctx context.Context, db postgres.DB, in *checks.ChecksFilter) (out *checks.AllChecks, err error)
+	GetChecksByRecipient(ctx context.Context, db postgres.DB, in *users.Id) (out *checks.AllChecks, err error)
 	GetCheckByKey(ctx context.Context, db postgres.DB, key string) (out *checks.Check, err error)
+	GetCheckById(ctx context.Context, db postgres.DB, in *checks.CheckId) (out *checks.Check, err error)
+	GetExpiredChecks(ctx context.Context, db postgres.DB) (out []*checks.CheckId, err error)
+
+	LockCreator(ctx context.Context, db postgres.DB, user *users.Id) (err error)
+	CountOpenChecks(ctx context.Context, db postgres.DB, user *users.Id) (count int64, err error)
+	CountChecksCreatedSince(ctx context.Context, db postgres.DB, user *users.Id, since time.Time) (count int64, err error)
+	GetLockedValue(ctx context.Context, db postgres.DB, user *users.Id) (out []*checks.Value, err error)
+
+	UserIsCheckCreator(in *checks.Check, user *users.Id) (b bool, err error)
+	UserIsModerator(user *users.User) (b bool, err error)
+	UserIsBlocked(user *users.User) (b bool, err error)
+	CheckIsActive(in *checks.Check) (b bool, err error)
+	CheckIsExpired(in *checks.Check) (b bool, err error)
+	CheckIsForUser(in *checks.Check, user *users.Id) (b bool, err error)
+	CheckPasswordIsValid(ctx context.Context, db postgres.DB, in *checks.CheckId, password string) (b bool, err error)
+	AddPasswordAttempt(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) (err error)
+	ResetPasswordAttempts(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) (err error)
+
+	CheckIsAlreadyActivated(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) (b bool, err error)
+	DecrementCheckActivations(ctx context.Context, db postgres.DB, in *checks.CheckId) (left int32, err error)
+	AddCheckActivationToHistory(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) (err error)
+	GetCheckActivations(ctx context.Context, db postgres.DB, in *checks.CheckId) (out []*checks.CheckActivation, err error)
+
+	CloseCheck(ctx context.Context, db postgres.DB, in *checks.CheckId, status checks.CheckStatus, user *users.Id) (out *checks.Check, err error)
+	SetCheckRedeemed(ctx context.Context, db postgres.DB, in *checks.CheckId, user *users.Id) (err error)
 }
 
-func NewServiceChecks(repo RepositoryChecks, db *pgxpool.Pool, users UserService) *ServiceChecks {
-	return &ServiceChecks{RepositoryChecks: repo, db: db, UserService: users}
+func NewServiceChecks(repo RepositoryChecks, db *pgxpool.Pool, signer *signer.Signer, cfg Config, users UserService) *ServiceChecks {
+	return &ServiceChecks{RepositoryChecks: repo, db: db, signer: signer, cfg: cfg, UserService: users}
 }
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"errors"
+	"protobuf/checks"
+
+	e "errorspomka"
+
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+// Size of QR code image in pixels
+const qrSize = 256
+
+// Make signed payload, deep link and QR code for key of check
+func (s *ServiceChecks) share(key string) (*checks.CheckShare, error) {
+
+	// Key is uuid, in payload it takes 16 bytes instead of 36 symbols
+	id, err := uuid.Parse(key)
+	if err != nil {
+		return nil, errors.Join(e.ErrCheckNotValid, err)
+	}
+
+	payload := s.signer.Sign(id[:])
+	url := s.cfg.LinkURL + payload
+
+	qr, err := qrcode.Encode(url, qrcode.Medium, qrSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checks.CheckShare{Payload: payload, Url: url, Qr: qr}, nil
+}
+
+// Get key of check from signed payload
+func (s *ServiceChecks) keyFromPayload(payload string) (string, error) {
+	data, err := s.signer.Verify(payload)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := uuid.FromBytes(data)
+	if err != nil {
+		return "", errors.Join(e.ErrBadSignature, err)
+	}
+
+	return id.String(), nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"protobuf/checks"
+	"protobuf/common"
+	"protobuf/users"
+
+	e "errorspomka"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Send transaction to service users. If service users refused transaction (not enough money, etc.), return its failure.
+func (s *ServiceChecks) sendTransaction(ctx context.Context, in *users.TransactionRequest) (*common.Failure, error) {
+	resp, err := s.UserService.SendTransaction(ctx, in)
+	if err != nil {
+		return nil, errors.Join(e.ErrSendTransaction, err)
+	}
+
+	if resp.GetFailure() != nil {
+		failure := resp.GetFailure().GetError()
+		return failure, errors.Join(e.ErrSendTransaction, errors.New(failure.GetCode().String()))
+	}
+
+	return nil, nil
+}
+
+// Send transaction to service users for every currency of check, amount of every part multiplied by times.
+// Request has sender or receiver without amount and currency, they are taken from parts of check.
+// Parts are all-or-nothing: if one of them failed, already sent parts are reversed.
+func (s *ServiceChecks) sendValues(ctx context.Context, in *users.TransactionRequest, check *checks.Check, times int64) (*common.Failure, error) {
+	var reqs, sent []*users.TransactionRequest
+
+	// Amounts of all parts counted before sending, so too big part doesn't break bundle
+	for _, value := range append([]*checks.Value{check.Value}, check.Bundle...) {
+		amount, err := multiply(value.Amount, times)
+		if err != nil {
+			return nil, err
+		}
+
+		req := proto.Clone(in).(*users.TransactionRequest)
+		for _, t := range []*users.UserTransaction{req.Sender, req.Receiver} {
+			if t != nil {
+				t.Amount, t.Currency = amount, value.Currency
+			}
+		}
+		reqs = append(reqs, req)
+	}
+
+	for _, req := range reqs {
+		if failure, err := s.sendTransaction(ctx, req); err != nil {
+			return failure, errors.Join(err, s.reverseValues(ctx, sent))
+		}
+
+		sent = append(sent, req)
+	}
+
+	return nil, nil
+}
+
+// Send reversed transactions to service users: sender gets money back, receiver returns money.
+// Context of request can be cancelled already, reverse must be sent anyway.
+func (s *ServiceChecks) reverseValues(ctx context.Context, sent []*users.TransactionRequest) error {
+	var errs []error
+
+	ctx = context.WithoutCancel(ctx)
+	for _, req := range sent {
+		reverse := &users.TransactionRequest{
+			Sender:   req.Receiver,
+			Receiver: req.Sender,
+			Type:     reverseType[req.Type],
+		}
+
+		if _, err := s.sendTransaction(ctx, reverse); err != nil {
+			errs = append(errs, errors.Join(e.ErrReverseTransaction, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Type of transaction, that cancels transaction of check
+var reverseType = map[common.TransactionType]common.TransactionType{
+	common.TransactionType_CreateCheck: common.TransactionType_DeleteCheck,
+	common.TransactionType_UseCheck:    common.TransactionType_CreateCheck,
+	common.TransactionType_DeleteCheck: common.TransactionType_CreateCheck,
+}
+
+// Multiply amount by times, error if result doesn't fit in int64
+func multiply(amount, times int64) (int64, error) {
+	if amount < 0 || times < 0 || (times > 0 && amount > math.MaxInt64/times) {
+		return 0, e.ErrCheckValueOverflow
+	}
+
+	return amount * times, nil
+}
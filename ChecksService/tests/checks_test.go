@@ -6,14 +6,21 @@ import (
 	"checks/tests/mock"
 	"config"
 	"context"
+	e "errorspomka"
 	"fmt"
 	"migrations"
 	"protobuf/checks"
 	"protobuf/common"
 	"protobuf/users"
 	"server"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 	"utils/hasher"
+	"utils/page"
+	"utils/signer"
 
 	"postgres"
 
@@ -23,15 +30,20 @@ import (
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var srv *server.Server
 var client checks.ChecksClient
 var serviceUsers *mock.MockServiceUsers
+var serviceChecks *service.ServiceChecks
 var dockerpostgres *mock.DockerPool
 var repo *repository.Repository
 var pool *pgxpool.Pool
 
+// Quota of amount for one activation in tests
+const maxAmount = 100_000
+
 func TestMain(m *testing.M) {
 
 	defer func() {
@@ -95,8 +107,9 @@ func TestMain(m *testing.M) {
 	repo = repository.NewRepository(hasher)
 
 	// Register promo service
-	service := service.NewServiceChecks(repo, pool, serviceUsers)
-	checks.RegisterChecksServer(grpcSrv, service)
+	serviceChecks = service.NewServiceChecks(repo, pool, signer.NewSigner(cfg.Storage.ChecksLinkSecret),
+		service.Config{LinkURL: cfg.Storage.ChecksLinkURL, Quotas: service.Quotas{MaxAmount: maxAmount}}, serviceUsers)
+	checks.RegisterChecksServer(grpcSrv, serviceChecks)
 
 	// Run server
 	srv = server.NewServer(grpcSrv)
@@ -124,6 +137,10 @@ func TestAll(t *testing.T) {
 	t.Cleanup(func() {
 		usersIds := []int64{creatorId}
 
+		if err := clearChecks(usersIds); err != nil {
+			t.Fatal()
+		}
+
 		if err := clearUsers(usersIds); err != nil {
 			t.Fatal()
 		}
@@ -141,9 +158,8 @@ func TestAll(t *testing.T) {
 		{
 			name: "common",
 			in: &checks.CheckCreate{
-				Creator:  creatorId,
-				Currency: common.Currency_Credits,
-				Amount:   999,
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 999},
 			},
 		},
 	}
@@ -157,25 +173,1081 @@ func TestAll(t *testing.T) {
 				t.Fail()
 			}
 
+			// Preview check before using
+			previewFailure, err := client.Preview(context.TODO(), &checks.CheckKey{Key: check.Check.Key})
+			if err != nil || !previewFailure.Preview.IsValid || previewFailure.Preview.Value.Amount != tt.in.Value.Amount {
+				t.Fail()
+			}
+
 			// Use check
-			if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: creatorId}); err != nil {
+			if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: creatorId}}); err != nil {
+				t.Fail()
+			}
+
+			// Preview check after using
+			previewFailure, err = client.Preview(context.TODO(), &checks.CheckKey{Key: check.Check.Key})
+			if err != nil || previewFailure.Preview.IsValid {
 				t.Fail()
 			}
 
 			// Get checks created by user
-			allChecksFailure, err := client.GetUserChecks(context.TODO(), &users.Id{Id: creatorId})
+			allChecksFailure, err := client.GetUserChecks(context.TODO(), &checks.ChecksFilter{UserId: creatorId})
+			if err != nil {
+				t.Fail()
+			}
+
+			// Proof check redeemed after using
+			if len(allChecksFailure.Checks.Checks) != 1 || allChecksFailure.Checks.Checks[0].Status != checks.CheckStatus_Redeemed {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	var creatorId, blockedId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId, blockedId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId, blockedId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	blockedId, err = serviceUsers.Create(context.TODO(), 0)
+	if err != nil {
+		t.Fatal()
+	}
+
+	var tests = []struct {
+		name string
+		in   *checks.CheckCreate
+		err  bool
+	}{
+		{
+			name: "with expiration",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 10},
+				ExpAt:   timestamppb.New(time.Now().Add(time.Hour)),
+			},
+			err: false,
+		},
+		{
+			name: "already expired",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 10},
+				ExpAt:   timestamppb.New(time.Now().Add(-time.Hour)),
+			},
+			err: true,
+		},
+		{
+			name: "bad arg amount",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 0},
+			},
+			err: true,
+		},
+		{
+			name: "bad arg currency",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: 10, Amount: 10},
+			},
+			err: true,
+		},
+		{
+			name: "amount over quota",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: maxAmount + 1},
+			},
+			err: true,
+		},
+		{
+			name: "blocked creator",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: blockedId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 10},
+			},
+			err: true,
+		},
+		{
+			name: "bundle",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+				Bundle:  []*checks.Value{{Currency: common.Currency_Stocks, Amount: 2}},
+			},
+			err: false,
+		},
+		{
+			name: "bundle with same currency",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+				Bundle:  []*checks.Value{{Currency: common.Currency_Credits, Amount: 2}},
+			},
+			err: true,
+		},
+		{
+			name: "bundle with too big amount",
+			in: &checks.CheckCreate{
+				Creator:     &users.Id{Id: creatorId},
+				Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+				Bundle:      []*checks.Value{{Currency: common.Currency_Stocks, Amount: 1 << 62}},
+				Activations: 4,
+			},
+			err: true,
+		},
+		{
+			name: "too many activations",
+			in: &checks.CheckCreate{
+				Creator:     &users.Id{Id: creatorId},
+				Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+				Activations: 1 << 30,
+			},
+			err: true,
+		},
+		{
+			name: "bundle with bad amount",
+			in: &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+				Bundle:  []*checks.Value{{Currency: common.Currency_Stocks, Amount: 0}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := client.Create(context.TODO(), tt.in)
+			if (err != nil) != tt.err {
+				t.Fail()
+			}
+
+			// Proof all parts of bundle saved
+			if err == nil && len(out.Check.Bundle) != len(tt.in.Bundle) {
+				t.Fail()
+			}
+
+			// Cancel created check, only for tests
+			if out != nil && out.Check != nil {
+				if _, err := client.Remove(context.TODO(), &checks.CheckCancel{Id: out.Check.Id, UserId: &users.Id{Id: creatorId}}); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestBundleAllOrNothing(t *testing.T) {
+	var creatorId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	// Creator can pay credits, but not stocks of bundle
+	serviceUsers.SetBalance(creatorId, common.Currency_Credits, 1000)
+	serviceUsers.SetBalance(creatorId, common.Currency_Stocks, 1)
+
+	if _, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator: &users.Id{Id: creatorId},
+		Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+		Bundle:  []*checks.Value{{Currency: common.Currency_Stocks, Amount: 2}},
+	}); err == nil {
+		t.Fatal()
+	}
+
+	t.Run("paid currency is returned", func(t *testing.T) {
+		if serviceUsers.Balance(creatorId, common.Currency_Credits) != 1000 || serviceUsers.Balance(creatorId, common.Currency_Stocks) != 1 {
+			t.Fail()
+		}
+	})
+
+	t.Run("check is not created", func(t *testing.T) {
+		allChecks, err := client.GetUserChecks(context.TODO(), &checks.ChecksFilter{UserId: creatorId})
+		if err != nil || len(allChecks.Checks.Checks) != 0 {
+			t.Fail()
+		}
+	})
+}
+
+func TestExpired(t *testing.T) {
+	var creatorId, userId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId, userId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	userId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	// Creator pays for three activations
+	serviceUsers.SetBalance(creatorId, common.Currency_Credits, 1000)
+	serviceUsers.SetBalance(creatorId, common.Currency_Stocks, 100)
+
+	check, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator:     &users.Id{Id: creatorId},
+		Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+		Bundle:      []*checks.Value{{Currency: common.Currency_Stocks, Amount: 10}},
+		Activations: 3,
+		ExpAt:       timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: userId}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Check expires
+	if _, err := pool.Exec(context.TODO(), `UPDATE "Checks" SET "ExpAt" = $2 WHERE "Id" = $1`,
+		check.Check.Id, time.Now().Add(-time.Minute).UTC().Format("2006-01-02 15:04:05")); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("use expired", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: creatorId}}); err == nil || !strings.Contains(err.Error(), e.ErrCheckExpired.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("refund", func(t *testing.T) {
+		if err := serviceChecks.RefundExpiredChecks(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+
+		// Creator gets every currency of two activations left
+		if serviceUsers.Balance(creatorId, common.Currency_Credits) != 900 || serviceUsers.Balance(creatorId, common.Currency_Stocks) != 90 {
+			t.Fail()
+		}
+
+		allChecks, err := client.GetUserChecks(context.TODO(), &checks.ChecksFilter{UserId: creatorId})
+		if err != nil || len(allChecks.Checks.Checks) != 1 || allChecks.Checks.Checks[0].Status != checks.CheckStatus_Expired {
+			t.Fail()
+		}
+	})
+
+	t.Run("second refund", func(t *testing.T) {
+		if err := serviceChecks.RefundExpiredChecks(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+
+		if serviceUsers.Balance(creatorId, common.Currency_Credits) != 900 || serviceUsers.Balance(creatorId, common.Currency_Stocks) != 90 {
+			t.Fail()
+		}
+	})
+}
+
+func TestQuotas(t *testing.T) {
+	var openId, lockedId, dailyId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{openId, lockedId, dailyId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{openId, lockedId, dailyId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	openId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+	lockedId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+	dailyId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	// Service with quotas, called without server
+	quotas := service.NewServiceChecks(repo, pool, signer.NewSigner("quotas"),
+		service.Config{Quotas: service.Quotas{MaxOpen: 3, MaxLockedValue: 1000, MaxPerDay: 5}}, serviceUsers)
+
+	create := func(creatorId, amount int64) (*checks.CheckFailure, error) {
+		return quotas.Create(context.TODO(), &checks.CheckCreate{
+			Creator: &users.Id{Id: creatorId},
+			Value:   &checks.Value{Currency: common.Currency_Credits, Amount: amount},
+		})
+	}
+
+	t.Run("max open checks", func(t *testing.T) {
+
+		// Parallel requests of creator pass quota one by one
+		var wg sync.WaitGroup
+		var created atomic.Int32
+		for range 6 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := create(openId, 10); err == nil {
+					created.Add(1)
+				} else if !strings.Contains(err.Error(), "max open checks") {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if created.Load() != 3 {
+			t.Fail()
+		}
+	})
+
+	t.Run("max locked value", func(t *testing.T) {
+		for range 2 {
+			if _, err := create(lockedId, 400); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := create(lockedId, 400); err == nil || !strings.Contains(err.Error(), "max locked value") {
+			t.Fail()
+		}
+	})
+
+	t.Run("max checks per day", func(t *testing.T) {
+
+		// Cancelled checks are not open, but they are created today
+		for range 5 {
+			out, err := create(dailyId, 10)
 			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := quotas.Remove(context.TODO(), &checks.CheckCancel{Id: out.Check.Id, UserId: &users.Id{Id: dailyId}}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := create(dailyId, 10); err == nil || !strings.Contains(err.Error(), "max checks per day") {
+			t.Fail()
+		}
+	})
+}
+
+func TestCreateBatch(t *testing.T) {
+	var creatorId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	var tests = []struct {
+		name  string
+		count int32
+		err   bool
+	}{
+		{
+			name:  "common",
+			count: 10,
+			err:   false,
+		},
+		{
+			name:  "empty batch",
+			count: 0,
+			err:   true,
+		},
+		{
+			name:  "too big batch",
+			count: 1000,
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allChecksFailure, err := client.CreateBatch(context.TODO(), &checks.CheckCreateBatch{
+				Check: &checks.CheckCreate{
+					Creator: &users.Id{Id: creatorId},
+					Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 10},
+				},
+				Count: tt.count,
+			})
+			if (err != nil) != tt.err {
 				t.Fail()
 			}
 
-			// Proof check deleting after using
-			if allChecksFailure.AllChecks.Checks != nil {
+			if err == nil && len(allChecksFailure.Checks.Checks) != int(tt.count) {
 				t.Fail()
 			}
 		})
 	}
 }
 
+func TestCreateBatchOverflow(t *testing.T) {
+	var creatorId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+	serviceUsers.SetBalance(creatorId, common.Currency_Credits, 1000)
+
+	// Total value of batch is 2^64, it must not be paid as 0
+	if _, err := client.CreateBatch(context.TODO(), &checks.CheckCreateBatch{
+		Check: &checks.CheckCreate{
+			Creator:     &users.Id{Id: creatorId},
+			Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 1 << 30},
+			Activations: 1 << 30,
+		},
+		Count: 16,
+	}); err == nil {
+		t.Fail()
+	}
+
+	if serviceUsers.Balance(creatorId, common.Currency_Credits) != 1000 {
+		t.Fail()
+	}
+
+	allChecks, err := client.GetUserChecks(context.TODO(), &checks.ChecksFilter{UserId: creatorId})
+	if err != nil || len(allChecks.Checks.Checks) != 0 {
+		t.Fail()
+	}
+}
+
+func TestGetUserChecks(t *testing.T) {
+	var creatorId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	// Create 5 checks with amounts from 10 to 50
+	for i := int64(1); i <= 5; i++ {
+		if _, err := client.Create(context.TODO(), &checks.CheckCreate{
+			Creator: &users.Id{Id: creatorId},
+			Value:   &checks.Value{Currency: common.Currency_Credits, Amount: i * 10},
+		}); err != nil {
+			t.Fatal()
+		}
+	}
+
+	// Walk all pages, newest first
+	var got []*checks.Check
+	var token string
+	for {
+		allChecksFailure, err := client.GetUserChecks(context.TODO(), &checks.ChecksFilter{UserId: creatorId, Limit: 2, PageToken: token})
+		if err != nil {
+			t.Fatal()
+		}
+
+		got = append(got, allChecksFailure.Checks.Checks...)
+		token = allChecksFailure.Checks.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+
+	if len(got) != 5 || got[0].Value.Amount != 50 || got[4].Value.Amount != 10 {
+		t.Fail()
+	}
+
+	minAmount, maxAmount := int64(20), int64(40)
+	currency := common.Currency_Credits
+	var tests = []struct {
+		name   string
+		filter *checks.ChecksFilter
+		count  int
+		err    bool
+	}{
+		{
+			name:   "amount range",
+			filter: &checks.ChecksFilter{UserId: creatorId, MinAmount: &minAmount, MaxAmount: &maxAmount},
+			count:  3,
+			err:    false,
+		},
+		{
+			name:   "currency",
+			filter: &checks.ChecksFilter{UserId: creatorId, Currency: &currency, OldestFirst: true},
+			count:  5,
+			err:    false,
+		},
+		{
+			name:   "bad page token",
+			filter: &checks.ChecksFilter{UserId: creatorId, PageToken: "bad token"},
+			err:    true,
+		},
+		{
+			name:   "too big limit",
+			filter: &checks.ChecksFilter{UserId: creatorId, Limit: 1000},
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allChecksFailure, err := client.GetUserChecks(context.TODO(), tt.filter)
+			if (err != nil) != tt.err {
+				t.Fail()
+			}
+
+			if err == nil && len(allChecksFailure.Checks.Checks) != tt.count {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	var creatorId, userId, moderId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId, userId, moderId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId, userId, moderId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	userId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	moderId, err = serviceUsers.Create(context.TODO(), 2)
+	if err != nil {
+		t.Fatal()
+	}
+
+	var tests = []struct {
+		name   string
+		userId int64
+		err    bool
+	}{
+		{
+			name:   "other user",
+			userId: userId,
+			err:    true,
+		},
+		{
+			name:   "creator",
+			userId: creatorId,
+			err:    false,
+		},
+		{
+			name:   "moderator",
+			userId: moderId,
+			err:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, err := client.Create(context.TODO(), &checks.CheckCreate{
+				Creator: &users.Id{Id: creatorId},
+				Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := client.Remove(context.TODO(), &checks.CheckCancel{Id: check.Check.Id, UserId: &users.Id{Id: tt.userId}}); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestActivations(t *testing.T) {
+	var creatorId, userId int64
+
+	t.Cleanup(func() {
+		usersIds := []int64{creatorId, userId}
+
+		if err := clearChecks(usersIds); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers(usersIds); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	userId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	// Create check for two users
+	check, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator:     &users.Id{Id: creatorId},
+		Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+		Activations: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name   string
+		userId int64
+		err    bool
+	}{
+		{
+			name:   "first activation",
+			userId: creatorId,
+			err:    false,
+		},
+		{
+			name:   "already activated",
+			userId: creatorId,
+			err:    true,
+		},
+		{
+			name:   "last activation",
+			userId: userId,
+			err:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: tt.userId}}); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+
+	// Proof check redeemed by last user after last activation
+	// Other user can't see who redeemed check
+	if _, err := client.GetLifecycle(context.TODO(), &checks.CheckLifecycleIn{Id: check.Check.Id, UserId: &users.Id{Id: userId}}); err == nil {
+		t.Fail()
+	}
+
+	lifecycleFailure, err := client.GetLifecycle(context.TODO(), &checks.CheckLifecycleIn{Id: check.Check.Id, UserId: &users.Id{Id: creatorId}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lifecycle := lifecycleFailure.Lifecycle
+	if lifecycle.Check.Status != checks.CheckStatus_Redeemed || lifecycle.Check.RedeemedBy.GetId() != userId || len(lifecycle.Activations) != 2 {
+		t.Fail()
+	}
+
+	t.Run("parallel last activations", func(t *testing.T) {
+		check, err := client.Create(context.TODO(), &checks.CheckCreate{
+			Creator:     &users.Id{Id: creatorId},
+			Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+			Activations: 2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Both users take last activations at once, one of them redeems check
+		var wg sync.WaitGroup
+		for _, id := range []int64{creatorId, userId} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: id}}); err != nil {
+					t.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		lifecycleFailure, err := client.GetLifecycle(context.TODO(), &checks.CheckLifecycleIn{Id: check.Check.Id, UserId: &users.Id{Id: creatorId}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lifecycleFailure.Lifecycle.Check.Status != checks.CheckStatus_Redeemed || lifecycleFailure.Lifecycle.Check.ActivationsLeft != 0 {
+			t.Fail()
+		}
+	})
+}
+
+func TestPassword(t *testing.T) {
+	var creatorId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	password, wrongPassword := "secret", "not secret"
+	check, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator:  &users.Id{Id: creatorId},
+		Value:    &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+		Password: &password,
+	})
+	if err != nil || !check.Check.HasPassword {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		password *string
+		err      bool
+	}{
+		{
+			name:     "without password",
+			password: nil,
+			err:      true,
+		},
+		{
+			name:     "wrong password",
+			password: &wrongPassword,
+			err:      true,
+		},
+		{
+			name:     "right password",
+			password: &password,
+			err:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Use(context.TODO(), &checks.CheckUse{
+				Key:      check.Check.Key,
+				UserId:   &users.Id{Id: creatorId},
+				Password: tt.password,
+			}); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestPasswordLock(t *testing.T) {
+	var creatorId, userId, attackerId, parallelId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId, userId, attackerId, parallelId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+	userId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+	attackerId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+	parallelId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	password, wrongPassword := "secret", "not secret"
+	check, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator:     &users.Id{Id: creatorId},
+		Value:       &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+		Activations: 3,
+		Password:    &password,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	use := func(userId int64, password *string) error {
+		_, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: userId}, Password: password})
+		return err
+	}
+
+	t.Run("locked after wrong passwords", func(t *testing.T) {
+		for range 5 {
+			if err := use(attackerId, &wrongPassword); err == nil || !strings.Contains(err.Error(), e.ErrCheckWrongPassword.Error()) {
+				t.Fatal(err)
+			}
+		}
+
+		if err := use(attackerId, &password); err == nil || !strings.Contains(err.Error(), e.ErrCheckLocked.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("other user is not locked", func(t *testing.T) {
+		if err := use(userId, &password); err != nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("parallel wrong passwords", func(t *testing.T) {
+		var wg sync.WaitGroup
+		var compared atomic.Int32
+		for range 20 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := use(parallelId, &wrongPassword); err != nil && strings.Contains(err.Error(), e.ErrCheckWrongPassword.Error()) {
+					compared.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Password compared only for attempts before lock
+		if compared.Load() != 5 {
+			t.Fail()
+		}
+	})
+}
+
+func TestRecipient(t *testing.T) {
+	var creatorId, recipientId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId, recipientId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId, recipientId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	recipientId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	check, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator:   &users.Id{Id: creatorId},
+		Value:     &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+		Recipient: &users.Id{Id: recipientId},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Proof check addressed to recipient
+	allChecksFailure, err := client.GetChecksToUser(context.TODO(), &users.Id{Id: recipientId})
+	if err != nil || len(allChecksFailure.Checks.Checks) != 1 {
+		t.Fail()
+	}
+
+	var tests = []struct {
+		name   string
+		userId int64
+		err    bool
+	}{
+		{
+			name:   "other user",
+			userId: creatorId,
+			err:    true,
+		},
+		{
+			name:   "recipient",
+			userId: recipientId,
+			err:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Use(context.TODO(), &checks.CheckUse{Key: check.Check.Key, UserId: &users.Id{Id: tt.userId}}); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	var creatorId int64
+
+	t.Cleanup(func() {
+		if err := clearChecks([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+
+		if err := clearUsers([]int64{creatorId}); err != nil {
+			t.Fatal()
+		}
+	})
+
+	creatorId, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal()
+	}
+
+	check, err := client.Create(context.TODO(), &checks.CheckCreate{
+		Creator: &users.Id{Id: creatorId},
+		Value:   &checks.Value{Currency: common.Currency_Credits, Amount: 100},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shareFailure, err := client.Share(context.TODO(), &checks.CheckKey{Key: check.Check.Key})
+	if err != nil || len(shareFailure.Share.Qr) == 0 {
+		t.Fatal(err)
+	}
+	payload := shareFailure.Share.Payload
+
+	// Change first symbol of payload, last symbol can have unused bits
+	forged := "A" + payload[1:]
+	if payload[0] == 'A' {
+		forged = "B" + payload[1:]
+	}
+
+	var tests = []struct {
+		name    string
+		payload string
+		err     bool
+	}{
+		{
+			name:    "forged payload",
+			payload: forged,
+			err:     true,
+		},
+		{
+			name:    "signed payload",
+			payload: payload,
+			err:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.Use(context.TODO(), &checks.CheckUse{Payload: &tt.payload, UserId: &users.Id{Id: creatorId}}); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func clearChecks(userIds []int64) error {
+	for _, userId := range userIds {
+		// Removed checks are gone from the next query, so always take first page
+		for {
+			allChecks, err := repo.GetUsersCheck(context.TODO(), pool, &checks.ChecksFilter{UserId: userId, Limit: page.MaxLimit})
+			if err != nil {
+				return err
+			}
+
+			if len(allChecks.Checks) == 0 {
+				break
+			}
+
+			for _, check := range allChecks.Checks {
+				if err := repo.DeleteCheckActivationsFromHistory(context.TODO(), pool, &checks.CheckId{Id: check.Id}); err != nil {
+					return err
+				}
+
+				if err := repo.RemoveCheck(context.TODO(), pool, &checks.CheckId{Id: check.Id}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
 func clearUsers(userIds []int64) error {
 	for _, userId := range userIds {
 
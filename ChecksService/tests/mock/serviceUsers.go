@@ -3,7 +3,9 @@ package mock
 import (
 	"context"
 	"fmt"
+	"protobuf/common"
 	"protobuf/users"
+	"sync"
 	"utils"
 
 	e "errorspomka"
@@ -16,10 +18,14 @@ import (
 
 type MockServiceUsers struct {
 	db *pgxpool.Pool
+
+	// Balances of users, only users with set balance are checked in transactions
+	mu       sync.Mutex
+	balances map[int64]map[common.Currency]int64
 }
 
 func NewMockServiceUsers(pool *pgxpool.Pool) *MockServiceUsers {
-	return &MockServiceUsers{db: pool}
+	return &MockServiceUsers{db: pool, balances: make(map[int64]map[common.Currency]int64)}
 }
 func (m *MockServiceUsers) GetUser(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*users.User, error) {
 	var user = new(users.User)
@@ -38,9 +44,48 @@ func (m *MockServiceUsers) GetUser(ctx context.Context, in *users.Id, opts ...gr
 }
 
 func (m *MockServiceUsers) SendTransaction(ctx context.Context, in *users.TransactionRequest, opts ...grpc.CallOption) (*users.TransactionResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if s := in.GetSender(); s != nil {
+		if balance, ok := m.balances[s.UserId]; ok {
+			if balance[s.Currency] < s.Amount {
+				return &users.TransactionResponse{
+					Failure: &users.FailedTransaction{Error: &common.Failure{Code: common.ErrorCode_NotEnoughMoney}},
+				}, nil
+			}
+			balance[s.Currency] -= s.Amount
+		}
+	}
+
+	if r := in.GetReceiver(); r != nil {
+		if balance, ok := m.balances[r.UserId]; ok {
+			balance[r.Currency] += r.Amount
+		}
+	}
+
 	return nil, nil
 }
 
+// Set balance of user, after it transactions of user are checked and change balance
+func (m *MockServiceUsers) SetBalance(userId int64, currency common.Currency, amount int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.balances[userId]; !ok {
+		m.balances[userId] = make(map[common.Currency]int64)
+	}
+	m.balances[userId][currency] = amount
+}
+
+// Get balance of user in currency
+func (m *MockServiceUsers) Balance(userId int64, currency common.Currency) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.balances[userId][currency]
+}
+
 func (m *MockServiceUsers) Create(ctx context.Context, role int) (int64, error) {
 	var userId = new(int64)
 	if errTx := utils.RunInTx(m.db, ctx, func(tx pgx.Tx) error {
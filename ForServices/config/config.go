@@ -67,6 +67,70 @@ func NewConfig() (Config, error) {
 		return Config{}, e.ErrMissingEnviroment
 	}
 
+	// Config expiration of bans, optional
+	warnsSweepIntervalS := 60
+	if warnsSweepInterval := os.Getenv("WARNS_SWEEP_INTERVAL_S"); warnsSweepInterval != "" {
+		warnsSweepIntervalS, err = strconv.Atoi(warnsSweepInterval)
+		if err != nil || warnsSweepIntervalS <= 0 {
+			return Config{}, e.ErrMissingEnviroment
+		}
+	}
+
+	// Config checks, optional
+	checksSweepIntervalS := 60
+	if checksSweepInterval := os.Getenv("CHECKS_SWEEP_INTERVAL_S"); checksSweepInterval != "" {
+		checksSweepIntervalS, err = strconv.Atoi(checksSweepInterval)
+		if err != nil || checksSweepIntervalS <= 0 {
+			return Config{}, e.ErrMissingEnviroment
+		}
+	}
+
+	// Config links of checks, secret is required only by service checks
+	checksLinkSecret, checksLinkURL :=
+		os.Getenv("CHECKS_LINK_SECRET"),
+		os.Getenv("CHECKS_LINK_URL")
+	if checksLinkURL == "" {
+		checksLinkURL = "pomka://check/"
+	}
+
+	// Config quotas of checks for one user, optional, 0 is no limit
+	var checksQuotas [5]int
+	for i, name := range []string{
+		"CHECKS_MAX_OPEN",
+		"CHECKS_MAX_LOCKED_VALUE",
+		"CHECKS_MIN_AMOUNT",
+		"CHECKS_MAX_AMOUNT",
+		"CHECKS_MAX_PER_DAY",
+	} {
+		if quota := os.Getenv(name); quota != "" {
+			checksQuotas[i], err = strconv.Atoi(quota)
+			if err != nil || checksQuotas[i] < 0 {
+				return Config{}, e.ErrMissingEnviroment
+			}
+		}
+	}
+
+	// Config rewards of referral codes, optional
+	promosReferralCurrency := 1
+	var promosReferralRewards [2]int
+	if currency := os.Getenv("PROMOS_REFERRAL_CURRENCY"); currency != "" {
+		promosReferralCurrency, err = strconv.Atoi(currency)
+		if err != nil || promosReferralCurrency < 1 || promosReferralCurrency > 2 {
+			return Config{}, e.ErrMissingEnviroment
+		}
+	}
+	for i, name := range []string{
+		"PROMOS_REFERRAL_REWARD",
+		"PROMOS_REFERRAL_OWNER_REWARD",
+	} {
+		if reward := os.Getenv(name); reward != "" {
+			promosReferralRewards[i], err = strconv.Atoi(reward)
+			if err != nil || promosReferralRewards[i] < 0 {
+				return Config{}, e.ErrMissingEnviroment
+			}
+		}
+	}
+
 	return Config{
 		Server: server.ServerConfig{
 			Network: srvNet,
@@ -88,8 +152,21 @@ func NewConfig() (Config, error) {
 			},
 		},
 		Storage: Storage{
-			HashSalt:       salt,
-			WarnsBeforeBan: warnsBeforeBanInt,
+			HashSalt:             salt,
+			WarnsBeforeBan:       warnsBeforeBanInt,
+			WarnsSweepIntervalS:  warnsSweepIntervalS,
+			ChecksSweepIntervalS: checksSweepIntervalS,
+			ChecksLinkSecret:     checksLinkSecret,
+			ChecksLinkURL:        checksLinkURL,
+			ChecksMaxOpen:        checksQuotas[0],
+			ChecksMaxLockedValue: int64(checksQuotas[1]),
+			ChecksMinAmount:      int64(checksQuotas[2]),
+			ChecksMaxAmount:      int64(checksQuotas[3]),
+			ChecksMaxPerDay:      checksQuotas[4],
+
+			PromosReferralCurrency:    promosReferralCurrency,
+			PromosReferralReward:      int64(promosReferralRewards[0]),
+			PromosReferralOwnerReward: int64(promosReferralRewards[1]),
 		},
 	}, nil
 }
@@ -3,4 +3,28 @@ package config
 type Storage struct {
 	WarnsBeforeBan int
 	HashSalt       string
+
+	// Interval between deactivations of expired bans
+	WarnsSweepIntervalS int
+
+	// Interval between refunds of expired checks
+	ChecksSweepIntervalS int
+
+	// Secret for signing links of checks and prefix of links
+	ChecksLinkSecret string
+	ChecksLinkURL    string
+
+	// Quotas of checks for one user, 0 is no limit.
+	// Locked value is value of activations left in open checks, counted for every currency.
+	// Min and max amount are for one activation.
+	ChecksMaxOpen        int
+	ChecksMaxLockedValue int64
+	ChecksMinAmount      int64
+	ChecksMaxAmount      int64
+	ChecksMaxPerDay      int
+
+	// Rewards of referral codes: new user gets reward, owner of code gets owner reward
+	PromosReferralCurrency    int
+	PromosReferralReward      int64
+	PromosReferralOwnerReward int64
 }
@@ -15,7 +15,26 @@ var (
 	ErrDoWithTries           = errors.New("error after %d attemps got fail")
 	ErrWrongTypeData         = errors.New("error not supported type data")
 	ErrSendTransaction       = errors.New("error send transaction to service users")
+	ErrReverseTransaction    = errors.New("error reverse sent transaction, balance of user must be fixed manually")
 	ErrCheckNotValid         = errors.New("check key invalid or missing")
+	ErrCheckBadArgs          = errors.New("error bad args: 0 < Currency <= 2 AND 0 < Amount <= 2147483647 AND 0 <= Activations <= 100000")
+	ErrCheckAlreadyActivated = errors.New("error check is already activated by user")
+	ErrCheckNotInStock       = errors.New("error check activations are over")
+	ErrCheckExpired          = errors.New("error check expired")
+	ErrCheckWrongPassword    = errors.New("error wrong password of check")
+	ErrCheckLocked           = errors.New("error too many wrong passwords, check is locked, try later")
+	ErrCheckForOtherUser     = errors.New("error check is addressed to other user")
+	ErrCheckNotActive        = errors.New("error check is already redeemed, cancelled or expired")
+	ErrCheckBatchSize        = errors.New("error count of checks in batch must be from 1 to 100")
+	ErrCheckCancel           = errors.New("error only creator of check or moderator can cancel it")
+	ErrCheckLifecycle        = errors.New("error only creator of check or moderator can see its lifecycle")
+	ErrCheckUserBlocked      = errors.New("error blocked user can't create checks")
+	ErrCheckQuota            = errors.New("error quota of checks exceeded")
+	ErrCheckBundle           = errors.New("error bad bundle: every currency of check once AND 0 < Amount <= 2147483647")
+	ErrCheckValueOverflow    = errors.New("error total value of checks is too big")
+	ErrBadSignature          = errors.New("error payload is forged or damaged")
+	ErrBadPageToken          = errors.New("error page token is damaged")
+	ErrBadPageLimit          = errors.New("error limit of page must be from 0 to 100")
 	ErrBadArgs               = errors.New("error bad args: 0 <= Currency <= 2 AND Amount >= 0 AND (Uses > 0 OR Uses = -1)")
 	ErrExpAt                 = errors.New("error timestamb of expired data must be more then now")
 	ErrCreatorIsNotOwner     = errors.New("error creator of promo must be have role owner")
@@ -24,8 +43,27 @@ var (
 	ErrMissingPromoId        = errors.New("error missing promo id")
 	ErrMissingPromoName      = errors.New("error missing promo name")
 	ErrPromoExpired          = errors.New("error promocode expired")
+	ErrPromoPaused           = errors.New("error promocode is paused")
+	ErrPromoDeleted          = errors.New("error promocode is deleted")
+	ErrPromoAudienceRole     = errors.New("error promocode is not for users with this role")
+	ErrPromoAudienceDate     = errors.New("error promocode is not for users created at this time")
+	ErrPromoAudienceUser     = errors.New("error promocode is not for this user")
+	ErrPromoNotStarted       = errors.New("error promocode is not started yet")
+	ErrStartsAt              = errors.New("error start of promo must be before expiration")
+	ErrClearStartsAt         = errors.New("error start of promo can't be set and cleared at once")
 	ErrPromoNotInStock       = errors.New("error promocode activations are over")
 	ErrPromoAlreadyActivated = errors.New("error promo is already activated by user")
+	ErrPromoUserLimit        = errors.New("error promo activations by user are over")
+	ErrPromoCooldown         = errors.New("error promo is on cooldown for user")
+	ErrPromoRewards          = errors.New("error bad rewards: 0 < UpTo of tier > UpTo of previous tier AND Weight of option > 0 AND 0 <= Currency <= 2 AND Amount >= 0")
+	ErrFileFormat            = errors.New("error bad file: CSV with header or JSON array of objects, 0 < rows <= 10000")
+	ErrMissingCampaignId     = errors.New("error missing campaign id")
+	ErrCampaignNotInStock    = errors.New("error campaign activations are over")
+	ErrCampaignCodes         = errors.New("error bad args: 0 < Count <= 10000 AND 6 <= Length <= 32 AND 2 <= unique symbols of Alphabet <= 64")
+	ErrReferralOwnCode       = errors.New("error user can't use own referral code")
+	ErrAlreadyReferred       = errors.New("error user already joined with referral code")
+	ErrReferralNotNewUser    = errors.New("error referral code is only for new users")
+	ErrReferralMutual        = errors.New("error user can't join with referral code of user, who joined with own code")
 	ErrUserIsNotModerator    = errors.New("error only moderators can give warns")
 	ErrCreateWarn            = errors.New("error create warn")
 	ErrCreateBan             = errors.New("error create ban")
@@ -35,4 +73,5 @@ var (
 	ErrMakeBansInActive      = errors.New("error make bans inactive")
 	ErrCountActiveWarns      = errors.New("error get count of active warns")
 	ErrUserAlreadyBanned     = errors.New("error user already banned")
+	ErrBanExpiresAt          = errors.New("error expiration of ban must be after now")
 )
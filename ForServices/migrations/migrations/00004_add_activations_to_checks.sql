@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    ADD COLUMN IF NOT EXISTS "Activations" INT NOT NULL DEFAULT 1 CHECK ("Activations" > 0),
+    ADD COLUMN IF NOT EXISTS "ActivationsLeft" INT NOT NULL DEFAULT 1 CHECK ("ActivationsLeft" >= 0);
+
+CREATE TABLE IF NOT EXISTS "UserToCheck" (
+    "UserId" BIGINT REFERENCES "Users"("Id"),
+    "CheckId" BIGINT REFERENCES "Checks"("Id"),
+    "ActivatedAt" TIMESTAMP NOT NULL,
+    UNIQUE ("UserId", "CheckId")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "UserToCheck";
+
+ALTER TABLE "Checks"
+    DROP COLUMN IF EXISTS "ActivationsLeft",
+    DROP COLUMN IF EXISTS "Activations";
+-- +goose StatementEnd
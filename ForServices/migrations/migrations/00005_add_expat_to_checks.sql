@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    ADD COLUMN IF NOT EXISTS "ExpAt" TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    DROP COLUMN IF EXISTS "ExpAt";
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    ADD COLUMN IF NOT EXISTS "Password" TEXT;
+
+-- Wrong passwords are counted for every user, so one user can't lock check for others
+CREATE TABLE IF NOT EXISTS "CheckPasswordAttempts" (
+    "CheckId" BIGINT REFERENCES "Checks"("Id") ON DELETE CASCADE,
+    "UserId" BIGINT REFERENCES "Users"("Id") ON DELETE CASCADE,
+    "Attempts" INT NOT NULL DEFAULT 0,
+    "LockedUntil" TIMESTAMP,
+    PRIMARY KEY ("CheckId", "UserId")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "CheckPasswordAttempts";
+
+ALTER TABLE "Checks"
+    DROP COLUMN IF EXISTS "Password";
+-- +goose StatementEnd
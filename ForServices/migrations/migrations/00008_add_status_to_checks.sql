@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    ADD COLUMN IF NOT EXISTS "Status" SMALLINT NOT NULL DEFAULT 0 CHECK ("Status" >= 0 AND "Status" <= 3),
+    ADD COLUMN IF NOT EXISTS "RedeemedBy" BIGINT REFERENCES "Users"("Id"),
+    ADD COLUMN IF NOT EXISTS "RedeemedAt" TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS "ClosedAt" TIMESTAMP;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    DROP COLUMN IF EXISTS "ClosedAt",
+    DROP COLUMN IF EXISTS "RedeemedAt",
+    DROP COLUMN IF EXISTS "RedeemedBy",
+    DROP COLUMN IF EXISTS "Status";
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    ADD COLUMN IF NOT EXISTS "CancelledBy" BIGINT REFERENCES "Users"("Id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Checks"
+    DROP COLUMN IF EXISTS "CancelledBy";
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "CheckBundles" (
+    "CheckId" BIGINT REFERENCES "Checks"("Id") ON DELETE CASCADE,
+    "Currency" SMALLINT NOT NULL CHECK ("Currency" = 1 OR "Currency" = 2),
+    "Amount" INT NOT NULL CHECK ("Amount" > 0),
+    UNIQUE ("CheckId", "Currency")
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "CheckBundles";
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "UserToPromo"
+    ADD COLUMN IF NOT EXISTS "Id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "UserToPromo"
+    DROP COLUMN IF EXISTS "Id";
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Promos, which names differ only by case or spaces, must be renamed by hand before migration
+DO $$
+DECLARE duplicates TEXT;
+BEGIN
+    SELECT string_agg(format('%s (%s)', "Normalized", "Names"), '; ') INTO duplicates
+    FROM (
+        SELECT lower(btrim(regexp_replace("Name", '\s+', ' ', 'g'))) AS "Normalized",
+            string_agg(quote_literal("Name"), ', ' ORDER BY "Id") AS "Names"
+        FROM "Promos"
+        GROUP BY 1
+        HAVING count(*) > 1
+    ) AS d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'names of promos are duplicated after normalization, rename them before migration: %', duplicates;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "PromosNormalizedName"
+    ON "Promos" (lower(btrim(regexp_replace("Name", '\s+', ' ', 'g'))));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "PromosNormalizedName";
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    ADD COLUMN IF NOT EXISTS "StartsAt" TIMESTAMP,
+    ADD CONSTRAINT "PromosStartsAtBeforeExpAt" CHECK ("StartsAt" IS NULL OR "StartsAt" < "ExpAt");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    DROP CONSTRAINT IF EXISTS "PromosStartsAtBeforeExpAt",
+    DROP COLUMN IF EXISTS "StartsAt";
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "Campaigns" (
+    "Id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "Name" VARCHAR(255) NOT NULL UNIQUE,
+    "Currency" SMALLINT CHECK ("Currency" = 0 OR "Currency" = 1 OR "Currency" = 2),
+    "Amount" INT NOT NULL CHECK ("Amount" >= 0),
+    "Uses" INT NOT NULL CHECK ("Uses" >= 0 OR "Uses" = -1),
+    "Creator" BIGINT REFERENCES "Users"("Id"),
+    "ExpAt" TIMESTAMP NOT NULL,
+    "CreatedAt" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "Codes" INT NOT NULL DEFAULT 0,
+    "Redeemed" INT NOT NULL DEFAULT 0
+);
+
+ALTER TABLE "Promos"
+    ADD COLUMN IF NOT EXISTS "CampaignId" BIGINT REFERENCES "Campaigns"("Id");
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    DROP COLUMN IF EXISTS "CampaignId";
+
+DROP TABLE IF EXISTS "Campaigns";
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    ADD COLUMN IF NOT EXISTS "AudienceRoles" SMALLINT[],
+    ADD COLUMN IF NOT EXISTS "AudienceCreatedAfter" TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS "AudienceCreatedBefore" TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS "AudienceUsers" BIGINT[];
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    DROP COLUMN IF EXISTS "AudienceUsers",
+    DROP COLUMN IF EXISTS "AudienceCreatedBefore",
+    DROP COLUMN IF EXISTS "AudienceCreatedAfter",
+    DROP COLUMN IF EXISTS "AudienceRoles";
+-- +goose StatementEnd
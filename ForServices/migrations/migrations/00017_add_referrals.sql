@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    ADD COLUMN IF NOT EXISTS "ReferrerId" BIGINT UNIQUE REFERENCES "Users"("Id"),
+    ADD COLUMN IF NOT EXISTS "ReferrerAmount" INT NOT NULL DEFAULT 0 CHECK ("ReferrerAmount" >= 0);
+
+CREATE TABLE IF NOT EXISTS "Referrals" (
+    "ReferrerId" BIGINT REFERENCES "Users"("Id"),
+    "UserId" BIGINT REFERENCES "Users"("Id") UNIQUE,
+    "PromoId" BIGINT REFERENCES "Promos"("Id") ON DELETE SET NULL,
+    "JoinedAt" TIMESTAMP NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS "Referrals";
+
+ALTER TABLE "Promos"
+    DROP COLUMN IF EXISTS "ReferrerAmount",
+    DROP COLUMN IF EXISTS "ReferrerId";
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    ADD COLUMN IF NOT EXISTS "UserLimit" INT NOT NULL DEFAULT 1 CHECK ("UserLimit" > 0 OR "UserLimit" = -1),
+    ADD COLUMN IF NOT EXISTS "Cooldown" BIGINT NOT NULL DEFAULT 0 CHECK ("Cooldown" >= 0); -- In seconds
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    DROP COLUMN IF EXISTS "Cooldown",
+    DROP COLUMN IF EXISTS "UserLimit";
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS "PromoRewards" (
+    "Id" BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,
+    "PromoId" BIGINT REFERENCES "Promos"("Id") ON DELETE CASCADE,
+    "UpTo" BIGINT CHECK ("UpTo" > 0), -- Set for tier
+    "Weight" INT CHECK ("Weight" > 0), -- Set for random option
+    "Currency" SMALLINT CHECK ("Currency" = 0 OR "Currency" = 1 OR "Currency" = 2),
+    "Amount" INT NOT NULL CHECK ("Amount" >= 0),
+    CHECK (("UpTo" IS NULL) != ("Weight" IS NULL))
+);
+
+-- Reward granted by activation
+ALTER TABLE "UserToPromo"
+    ADD COLUMN IF NOT EXISTS "Currency" SMALLINT,
+    ADD COLUMN IF NOT EXISTS "Amount" INT;
+
+UPDATE "UserToPromo" SET "Currency" = "Promos"."Currency", "Amount" = "Promos"."Amount"
+FROM "Promos"
+WHERE "UserToPromo"."PromoId" = "Promos"."Id";
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE "UserToPromo"
+    DROP COLUMN IF EXISTS "Amount",
+    DROP COLUMN IF EXISTS "Currency";
+
+DROP TABLE IF EXISTS "PromoRewards";
+-- +goose StatementEnd
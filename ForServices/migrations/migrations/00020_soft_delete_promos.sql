@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE "Promos"
+    ADD COLUMN IF NOT EXISTS "DeletedAt" TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS "DeletedBy" BIGINT REFERENCES "Users"("Id");
+
+-- Deleted promo keeps its history, but its name and referral code can be used again
+ALTER TABLE "Promos"
+    DROP CONSTRAINT IF EXISTS "Promos_Name_key",
+    DROP CONSTRAINT IF EXISTS "Promos_ReferrerId_key";
+DROP INDEX IF EXISTS "PromosNormalizedName";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "PromosName"
+    ON "Promos" ("Name") WHERE "DeletedAt" IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "PromosNormalizedName"
+    ON "Promos" (lower(btrim(regexp_replace("Name", '\s+', ' ', 'g')))) WHERE "DeletedAt" IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "PromosReferrerId"
+    ON "Promos" ("ReferrerId") WHERE "DeletedAt" IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS "PromosReferrerId";
+DROP INDEX IF EXISTS "PromosNormalizedName";
+DROP INDEX IF EXISTS "PromosName";
+
+CREATE UNIQUE INDEX IF NOT EXISTS "PromosNormalizedName"
+    ON "Promos" (lower(btrim(regexp_replace("Name", '\s+', ' ', 'g'))));
+ALTER TABLE "Promos"
+    ADD CONSTRAINT "Promos_Name_key" UNIQUE ("Name"),
+    ADD CONSTRAINT "Promos_ReferrerId_key" UNIQUE ("ReferrerId");
+
+ALTER TABLE "Promos"
+    DROP COLUMN IF EXISTS "DeletedBy",
+    DROP COLUMN IF EXISTS "DeletedAt";
+-- +goose StatementEnd
@@ -7,11 +7,11 @@
 package checks
 
 import (
-	common "protobuf/common"
-	users "protobuf/users"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	common "protobuf/common"
+	users "protobuf/users"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,16 +24,78 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CheckStatus int32
+
+const (
+	CheckStatus_Active    CheckStatus = 0
+	CheckStatus_Redeemed  CheckStatus = 1 // All activations are used
+	CheckStatus_Cancelled CheckStatus = 2
+	CheckStatus_Expired   CheckStatus = 3
+)
+
+// Enum value maps for CheckStatus.
+var (
+	CheckStatus_name = map[int32]string{
+		0: "Active",
+		1: "Redeemed",
+		2: "Cancelled",
+		3: "Expired",
+	}
+	CheckStatus_value = map[string]int32{
+		"Active":    0,
+		"Redeemed":  1,
+		"Cancelled": 2,
+		"Expired":   3,
+	}
+)
+
+func (x CheckStatus) Enum() *CheckStatus {
+	p := new(CheckStatus)
+	*p = x
+	return p
+}
+
+func (x CheckStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_checks_service_proto_enumTypes[0].Descriptor()
+}
+
+func (CheckStatus) Type() protoreflect.EnumType {
+	return &file_checks_service_proto_enumTypes[0]
+}
+
+func (x CheckStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckStatus.Descriptor instead.
+func (CheckStatus) EnumDescriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{0}
+}
+
 type Check struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
-	Currency      common.Currency        `protobuf:"varint,3,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
-	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Creator       int64                  `protobuf:"varint,5,opt,name=creator,proto3" json:"creator,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Key             string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value           *Value                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Creator         *users.Id              `protobuf:"bytes,4,opt,name=creator,proto3" json:"creator,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Activations     int32                  `protobuf:"varint,6,opt,name=activations,proto3" json:"activations,omitempty"`
+	ActivationsLeft int32                  `protobuf:"varint,7,opt,name=activationsLeft,proto3" json:"activationsLeft,omitempty"`
+	ExpAt           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expAt,proto3" json:"expAt,omitempty"` // Empty if check never expires
+	HasPassword     bool                   `protobuf:"varint,9,opt,name=hasPassword,proto3" json:"hasPassword,omitempty"`
+	Recipient       *users.Id              `protobuf:"bytes,10,opt,name=recipient,proto3" json:"recipient,omitempty"` // Empty if check can be used by anyone
+	Status          CheckStatus            `protobuf:"varint,11,opt,name=status,proto3,enum=checks.CheckStatus" json:"status,omitempty"`
+	RedeemedBy      *users.Id              `protobuf:"bytes,12,opt,name=redeemedBy,proto3" json:"redeemedBy,omitempty"` // User who used last activation
+	RedeemedAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=redeemedAt,proto3" json:"redeemedAt,omitempty"`
+	ClosedAt        *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=closedAt,proto3" json:"closedAt,omitempty"`       // Time when check stopped being active
+	CancelledBy     *users.Id              `protobuf:"bytes,15,opt,name=cancelledBy,proto3" json:"cancelledBy,omitempty"` // Creator or moderator, who cancelled check
+	Bundle          []*Value               `protobuf:"bytes,16,rep,name=bundle,proto3" json:"bundle,omitempty"`           // Other currencies given with value by one activation
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Check) Reset() {
@@ -80,56 +142,126 @@ func (x *Check) GetKey() string {
 	return ""
 }
 
-func (x *Check) GetCurrency() common.Currency {
+func (x *Check) GetValue() *Value {
 	if x != nil {
-		return x.Currency
+		return x.Value
 	}
-	return common.Currency(0)
+	return nil
 }
 
-func (x *Check) GetAmount() int64 {
+func (x *Check) GetCreator() *users.Id {
 	if x != nil {
-		return x.Amount
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *Check) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Check) GetActivations() int32 {
+	if x != nil {
+		return x.Activations
 	}
 	return 0
 }
 
-func (x *Check) GetCreator() int64 {
+func (x *Check) GetActivationsLeft() int32 {
 	if x != nil {
-		return x.Creator
+		return x.ActivationsLeft
 	}
 	return 0
 }
 
-func (x *Check) GetCreatedAt() *timestamppb.Timestamp {
+func (x *Check) GetExpAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.CreatedAt
+		return x.ExpAt
 	}
 	return nil
 }
 
-type CheckFailure struct {
+func (x *Check) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *Check) GetRecipient() *users.Id {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *Check) GetStatus() CheckStatus {
+	if x != nil {
+		return x.Status
+	}
+	return CheckStatus_Active
+}
+
+func (x *Check) GetRedeemedBy() *users.Id {
+	if x != nil {
+		return x.RedeemedBy
+	}
+	return nil
+}
+
+func (x *Check) GetRedeemedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RedeemedAt
+	}
+	return nil
+}
+
+func (x *Check) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Check) GetCancelledBy() *users.Id {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return nil
+}
+
+func (x *Check) GetBundle() []*Value {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type CheckActivation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Check         *Check                 `protobuf:"bytes,1,opt,name=check,proto3,oneof" json:"check,omitempty"`
-	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	User          *users.Id              `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	ActivatedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=activatedAt,proto3" json:"activatedAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckFailure) Reset() {
-	*x = CheckFailure{}
+func (x *CheckActivation) Reset() {
+	*x = CheckActivation{}
 	mi := &file_checks_service_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckFailure) String() string {
+func (x *CheckActivation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckFailure) ProtoMessage() {}
+func (*CheckActivation) ProtoMessage() {}
 
-func (x *CheckFailure) ProtoReflect() protoreflect.Message {
+func (x *CheckActivation) ProtoReflect() protoreflect.Message {
 	mi := &file_checks_service_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -141,46 +273,47 @@ func (x *CheckFailure) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckFailure.ProtoReflect.Descriptor instead.
-func (*CheckFailure) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckActivation.ProtoReflect.Descriptor instead.
+func (*CheckActivation) Descriptor() ([]byte, []int) {
 	return file_checks_service_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CheckFailure) GetCheck() *Check {
+func (x *CheckActivation) GetUser() *users.Id {
 	if x != nil {
-		return x.Check
+		return x.User
 	}
 	return nil
 }
 
-func (x *CheckFailure) GetFailure() *common.Failure {
+func (x *CheckActivation) GetActivatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Failure
+		return x.ActivatedAt
 	}
 	return nil
 }
 
-type AllChecks struct {
+type CheckLifecycle struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Checks        []*Check               `protobuf:"bytes,1,rep,name=Checks,proto3" json:"Checks,omitempty"`
+	Check         *Check                 `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	Activations   []*CheckActivation     `protobuf:"bytes,2,rep,name=activations,proto3" json:"activations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AllChecks) Reset() {
-	*x = AllChecks{}
+func (x *CheckLifecycle) Reset() {
+	*x = CheckLifecycle{}
 	mi := &file_checks_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AllChecks) String() string {
+func (x *CheckLifecycle) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AllChecks) ProtoMessage() {}
+func (*CheckLifecycle) ProtoMessage() {}
 
-func (x *AllChecks) ProtoReflect() protoreflect.Message {
+func (x *CheckLifecycle) ProtoReflect() protoreflect.Message {
 	mi := &file_checks_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -192,40 +325,47 @@ func (x *AllChecks) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AllChecks.ProtoReflect.Descriptor instead.
-func (*AllChecks) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckLifecycle.ProtoReflect.Descriptor instead.
+func (*CheckLifecycle) Descriptor() ([]byte, []int) {
 	return file_checks_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *AllChecks) GetChecks() []*Check {
+func (x *CheckLifecycle) GetCheck() *Check {
 	if x != nil {
-		return x.Checks
+		return x.Check
 	}
 	return nil
 }
 
-type AllChecksFailure struct {
+func (x *CheckLifecycle) GetActivations() []*CheckActivation {
+	if x != nil {
+		return x.Activations
+	}
+	return nil
+}
+
+type CheckLifecycleFailure struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AllChecks     *AllChecks             `protobuf:"bytes,1,opt,name=allChecks,proto3,oneof" json:"allChecks,omitempty"`
+	Lifecycle     *CheckLifecycle        `protobuf:"bytes,1,opt,name=lifecycle,proto3,oneof" json:"lifecycle,omitempty"`
 	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AllChecksFailure) Reset() {
-	*x = AllChecksFailure{}
+func (x *CheckLifecycleFailure) Reset() {
+	*x = CheckLifecycleFailure{}
 	mi := &file_checks_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AllChecksFailure) String() string {
+func (x *CheckLifecycleFailure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AllChecksFailure) ProtoMessage() {}
+func (*CheckLifecycleFailure) ProtoMessage() {}
 
-func (x *AllChecksFailure) ProtoReflect() protoreflect.Message {
+func (x *CheckLifecycleFailure) ProtoReflect() protoreflect.Message {
 	mi := &file_checks_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -237,48 +377,54 @@ func (x *AllChecksFailure) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AllChecksFailure.ProtoReflect.Descriptor instead.
-func (*AllChecksFailure) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckLifecycleFailure.ProtoReflect.Descriptor instead.
+func (*CheckLifecycleFailure) Descriptor() ([]byte, []int) {
 	return file_checks_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *AllChecksFailure) GetAllChecks() *AllChecks {
+func (x *CheckLifecycleFailure) GetLifecycle() *CheckLifecycle {
 	if x != nil {
-		return x.AllChecks
+		return x.Lifecycle
 	}
 	return nil
 }
 
-func (x *AllChecksFailure) GetFailure() *common.Failure {
+func (x *CheckLifecycleFailure) GetFailure() *common.Failure {
 	if x != nil {
 		return x.Failure
 	}
 	return nil
 }
 
-type CheckCreate struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Creator       int64                  `protobuf:"varint,1,opt,name=creator,proto3" json:"creator,omitempty"`
-	Currency      common.Currency        `protobuf:"varint,2,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
-	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type CheckPreview struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Value           *Value                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Creator         *users.Id              `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	IsValid         bool                   `protobuf:"varint,3,opt,name=isValid,proto3" json:"isValid,omitempty"`
+	Reason          *string                `protobuf:"bytes,4,opt,name=reason,proto3,oneof" json:"reason,omitempty"` // Why check can't be used, if not valid
+	ActivationsLeft int32                  `protobuf:"varint,5,opt,name=activationsLeft,proto3" json:"activationsLeft,omitempty"`
+	HasPassword     bool                   `protobuf:"varint,6,opt,name=hasPassword,proto3" json:"hasPassword,omitempty"`
+	ExpAt           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expAt,proto3" json:"expAt,omitempty"`
+	Recipient       *users.Id              `protobuf:"bytes,8,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Bundle          []*Value               `protobuf:"bytes,9,rep,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *CheckCreate) Reset() {
-	*x = CheckCreate{}
+func (x *CheckPreview) Reset() {
+	*x = CheckPreview{}
 	mi := &file_checks_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckCreate) String() string {
+func (x *CheckPreview) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckCreate) ProtoMessage() {}
+func (*CheckPreview) ProtoMessage() {}
 
-func (x *CheckCreate) ProtoReflect() protoreflect.Message {
+func (x *CheckPreview) ProtoReflect() protoreflect.Message {
 	mi := &file_checks_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -290,54 +436,96 @@ func (x *CheckCreate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckCreate.ProtoReflect.Descriptor instead.
-func (*CheckCreate) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckPreview.ProtoReflect.Descriptor instead.
+func (*CheckPreview) Descriptor() ([]byte, []int) {
 	return file_checks_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CheckCreate) GetCreator() int64 {
+func (x *CheckPreview) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CheckPreview) GetCreator() *users.Id {
 	if x != nil {
 		return x.Creator
 	}
-	return 0
+	return nil
 }
 
-func (x *CheckCreate) GetCurrency() common.Currency {
+func (x *CheckPreview) GetIsValid() bool {
 	if x != nil {
-		return x.Currency
+		return x.IsValid
 	}
-	return common.Currency(0)
+	return false
+}
+
+func (x *CheckPreview) GetReason() string {
+	if x != nil && x.Reason != nil {
+		return *x.Reason
+	}
+	return ""
 }
 
-func (x *CheckCreate) GetAmount() int64 {
+func (x *CheckPreview) GetActivationsLeft() int32 {
 	if x != nil {
-		return x.Amount
+		return x.ActivationsLeft
 	}
 	return 0
 }
 
-type CheckUse struct {
+func (x *CheckPreview) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *CheckPreview) GetExpAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpAt
+	}
+	return nil
+}
+
+func (x *CheckPreview) GetRecipient() *users.Id {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *CheckPreview) GetBundle() []*Value {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type CheckPreviewFailure struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Preview       *CheckPreview          `protobuf:"bytes,1,opt,name=preview,proto3,oneof" json:"preview,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckUse) Reset() {
-	*x = CheckUse{}
+func (x *CheckPreviewFailure) Reset() {
+	*x = CheckPreviewFailure{}
 	mi := &file_checks_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckUse) String() string {
+func (x *CheckPreviewFailure) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckUse) ProtoMessage() {}
+func (*CheckPreviewFailure) ProtoMessage() {}
 
-func (x *CheckUse) ProtoReflect() protoreflect.Message {
+func (x *CheckPreviewFailure) ProtoReflect() protoreflect.Message {
 	mi := &file_checks_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -349,46 +537,48 @@ func (x *CheckUse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckUse.ProtoReflect.Descriptor instead.
-func (*CheckUse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckPreviewFailure.ProtoReflect.Descriptor instead.
+func (*CheckPreviewFailure) Descriptor() ([]byte, []int) {
 	return file_checks_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *CheckUse) GetUserId() int64 {
+func (x *CheckPreviewFailure) GetPreview() *CheckPreview {
 	if x != nil {
-		return x.UserId
+		return x.Preview
 	}
-	return 0
+	return nil
 }
 
-func (x *CheckUse) GetKey() string {
+func (x *CheckPreviewFailure) GetFailure() *common.Failure {
 	if x != nil {
-		return x.Key
+		return x.Failure
 	}
-	return ""
+	return nil
 }
 
-type CheckId struct {
+type CheckShare struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Payload       string                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Qr            []byte                 `protobuf:"bytes,3,opt,name=qr,proto3" json:"qr,omitempty"` // PNG image of QR code with url
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckId) Reset() {
-	*x = CheckId{}
+func (x *CheckShare) Reset() {
+	*x = CheckShare{}
 	mi := &file_checks_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckId) String() string {
+func (x *CheckShare) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckId) ProtoMessage() {}
+func (*CheckShare) ProtoMessage() {}
 
-func (x *CheckId) ProtoReflect() protoreflect.Message {
+func (x *CheckShare) ProtoReflect() protoreflect.Message {
 	mi := &file_checks_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -400,59 +590,934 @@ func (x *CheckId) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckId.ProtoReflect.Descriptor instead.
-func (*CheckId) Descriptor() ([]byte, []int) {
+// Deprecated: Use CheckShare.ProtoReflect.Descriptor instead.
+func (*CheckShare) Descriptor() ([]byte, []int) {
 	return file_checks_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CheckId) GetId() int64 {
+func (x *CheckShare) GetPayload() string {
 	if x != nil {
-		return x.Id
+		return x.Payload
 	}
-	return 0
+	return ""
 }
 
-var File_checks_service_proto protoreflect.FileDescriptor
+func (x *CheckShare) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
 
-const file_checks_service_proto_rawDesc = "" +
-	"\n" +
-	"\x14checks/service.proto\x12\x06checks\x1a\x12common/types.proto\x1a\x13users/service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\x01\n" +
-	"\x05Check\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\x12,\n" +
-	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x18\n" +
-	"\acreator\x18\x05 \x01(\x03R\acreator\x128\n" +
-	"\tcreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"~\n" +
-	"\fCheckFailure\x12(\n" +
-	"\x05check\x18\x01 \x01(\v2\r.checks.CheckH\x00R\x05check\x88\x01\x01\x12.\n" +
-	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
-	"\x06_checkB\n" +
-	"\n" +
-	"\b_failure\"2\n" +
-	"\tAllChecks\x12%\n" +
-	"\x06Checks\x18\x01 \x03(\v2\r.checks.CheckR\x06Checks\"\x92\x01\n" +
-	"\x10AllChecksFailure\x124\n" +
-	"\tallChecks\x18\x01 \x01(\v2\x11.checks.AllChecksH\x00R\tallChecks\x88\x01\x01\x12.\n" +
-	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\f\n" +
-	"\n" +
-	"_allChecksB\n" +
-	"\n" +
-	"\b_failure\"m\n" +
-	"\vCheckCreate\x12\x18\n" +
-	"\acreator\x18\x01 \x01(\x03R\acreator\x12,\n" +
-	"\bcurrency\x18\x02 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x03R\x06amount\"4\n" +
-	"\bCheckUse\x12\x16\n" +
-	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\"\x19\n" +
-	"\aCheckId\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id2\xcb\x01\n" +
+func (x *CheckShare) GetQr() []byte {
+	if x != nil {
+		return x.Qr
+	}
+	return nil
+}
+
+type CheckShareFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *CheckShare            `protobuf:"bytes,1,opt,name=share,proto3,oneof" json:"share,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckShareFailure) Reset() {
+	*x = CheckShareFailure{}
+	mi := &file_checks_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckShareFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckShareFailure) ProtoMessage() {}
+
+func (x *CheckShareFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckShareFailure.ProtoReflect.Descriptor instead.
+func (*CheckShareFailure) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckShareFailure) GetShare() *CheckShare {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *CheckShareFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type CheckFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         *Check                 `protobuf:"bytes,1,opt,name=check,proto3,oneof" json:"check,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckFailure) Reset() {
+	*x = CheckFailure{}
+	mi := &file_checks_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckFailure) ProtoMessage() {}
+
+func (x *CheckFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckFailure.ProtoReflect.Descriptor instead.
+func (*CheckFailure) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CheckFailure) GetCheck() *Check {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *CheckFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type Value struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      common.Currency        `protobuf:"varint,1,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_checks_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Value) GetCurrency() common.Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *Value) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type AllChecks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checks        []*Check               `protobuf:"bytes,1,rep,name=checks,proto3" json:"checks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty if it is last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllChecks) Reset() {
+	*x = AllChecks{}
+	mi := &file_checks_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllChecks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllChecks) ProtoMessage() {}
+
+func (x *AllChecks) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllChecks.ProtoReflect.Descriptor instead.
+func (*AllChecks) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AllChecks) GetChecks() []*Check {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *AllChecks) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ChecksFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`                                // Creator of checks
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                  // Size of page, 0 is default size
+	PageToken     string                 `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"`                           // Empty for first page
+	OldestFirst   bool                   `protobuf:"varint,4,opt,name=oldestFirst,proto3" json:"oldestFirst,omitempty"`                      // By default newest checks first
+	Currency      *common.Currency       `protobuf:"varint,5,opt,name=currency,proto3,enum=common.Currency,oneof" json:"currency,omitempty"` // Currency of value or of any part of bundle
+	MinAmount     *int64                 `protobuf:"varint,6,opt,name=minAmount,proto3,oneof" json:"minAmount,omitempty"`                    // Amount of value
+	MaxAmount     *int64                 `protobuf:"varint,7,opt,name=maxAmount,proto3,oneof" json:"maxAmount,omitempty"`                    // Amount of value
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecksFilter) Reset() {
+	*x = ChecksFilter{}
+	mi := &file_checks_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecksFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecksFilter) ProtoMessage() {}
+
+func (x *ChecksFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecksFilter.ProtoReflect.Descriptor instead.
+func (*ChecksFilter) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ChecksFilter) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ChecksFilter) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ChecksFilter) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ChecksFilter) GetOldestFirst() bool {
+	if x != nil {
+		return x.OldestFirst
+	}
+	return false
+}
+
+func (x *ChecksFilter) GetCurrency() common.Currency {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *ChecksFilter) GetMinAmount() int64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *ChecksFilter) GetMaxAmount() int64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+type AllChecksFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Checks        *AllChecks             `protobuf:"bytes,1,opt,name=checks,proto3,oneof" json:"checks,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllChecksFailure) Reset() {
+	*x = AllChecksFailure{}
+	mi := &file_checks_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllChecksFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllChecksFailure) ProtoMessage() {}
+
+func (x *AllChecksFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllChecksFailure.ProtoReflect.Descriptor instead.
+func (*AllChecksFailure) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AllChecksFailure) GetChecks() *AllChecks {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *AllChecksFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type CheckCreate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Creator       *users.Id              `protobuf:"bytes,1,opt,name=creator,proto3" json:"creator,omitempty"`
+	Value         *Value                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`              // Value for one activation
+	Activations   int32                  `protobuf:"varint,3,opt,name=activations,proto3" json:"activations,omitempty"` // Count of users who can use check, 0 is one activation
+	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expAt,proto3" json:"expAt,omitempty"`              // Optional, after expiration unused value returns to creator
+	Password      *string                `protobuf:"bytes,5,opt,name=password,proto3,oneof" json:"password,omitempty"`
+	Recipient     *users.Id              `protobuf:"bytes,6,opt,name=recipient,proto3" json:"recipient,omitempty"` // Optional, only this user can use check
+	Bundle        []*Value               `protobuf:"bytes,7,rep,name=bundle,proto3" json:"bundle,omitempty"`       // Optional, other currencies for one activation, every currency once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCreate) Reset() {
+	*x = CheckCreate{}
+	mi := &file_checks_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCreate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCreate) ProtoMessage() {}
+
+func (x *CheckCreate) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCreate.ProtoReflect.Descriptor instead.
+func (*CheckCreate) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CheckCreate) GetCreator() *users.Id {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *CheckCreate) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *CheckCreate) GetActivations() int32 {
+	if x != nil {
+		return x.Activations
+	}
+	return 0
+}
+
+func (x *CheckCreate) GetExpAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpAt
+	}
+	return nil
+}
+
+func (x *CheckCreate) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *CheckCreate) GetRecipient() *users.Id {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *CheckCreate) GetBundle() []*Value {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type CheckCreateBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Check         *CheckCreate           `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"` // Every check in batch created from it
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCreateBatch) Reset() {
+	*x = CheckCreateBatch{}
+	mi := &file_checks_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCreateBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCreateBatch) ProtoMessage() {}
+
+func (x *CheckCreateBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCreateBatch.ProtoReflect.Descriptor instead.
+func (*CheckCreateBatch) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CheckCreateBatch) GetCheck() *CheckCreate {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *CheckCreateBatch) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CheckUse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        *users.Id              `protobuf:"bytes,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Password      *string                `protobuf:"bytes,3,opt,name=password,proto3,oneof" json:"password,omitempty"` // Required if check created with password
+	Payload       *string                `protobuf:"bytes,4,opt,name=payload,proto3,oneof" json:"payload,omitempty"`   // Signed payload from Share, can be used instead of key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckUse) Reset() {
+	*x = CheckUse{}
+	mi := &file_checks_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckUse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckUse) ProtoMessage() {}
+
+func (x *CheckUse) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckUse.ProtoReflect.Descriptor instead.
+func (*CheckUse) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CheckUse) GetUserId() *users.Id {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *CheckUse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CheckUse) GetPassword() string {
+	if x != nil && x.Password != nil {
+		return *x.Password
+	}
+	return ""
+}
+
+func (x *CheckUse) GetPayload() string {
+	if x != nil && x.Payload != nil {
+		return *x.Payload
+	}
+	return ""
+}
+
+type CheckId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckId) Reset() {
+	*x = CheckId{}
+	mi := &file_checks_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckId) ProtoMessage() {}
+
+func (x *CheckId) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckId.ProtoReflect.Descriptor instead.
+func (*CheckId) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CheckId) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CheckCancel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        *users.Id              `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"` // Who cancels check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckCancel) Reset() {
+	*x = CheckCancel{}
+	mi := &file_checks_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckCancel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckCancel) ProtoMessage() {}
+
+func (x *CheckCancel) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckCancel.ProtoReflect.Descriptor instead.
+func (*CheckCancel) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CheckCancel) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CheckCancel) GetUserId() *users.Id {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+type CheckLifecycleIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        *users.Id              `protobuf:"bytes,2,opt,name=userId,proto3" json:"userId,omitempty"` // Who asks lifecycle of check
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckLifecycleIn) Reset() {
+	*x = CheckLifecycleIn{}
+	mi := &file_checks_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckLifecycleIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckLifecycleIn) ProtoMessage() {}
+
+func (x *CheckLifecycleIn) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckLifecycleIn.ProtoReflect.Descriptor instead.
+func (*CheckLifecycleIn) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CheckLifecycleIn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CheckLifecycleIn) GetUserId() *users.Id {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+type CheckKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckKey) Reset() {
+	*x = CheckKey{}
+	mi := &file_checks_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckKey) ProtoMessage() {}
+
+func (x *CheckKey) ProtoReflect() protoreflect.Message {
+	mi := &file_checks_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckKey.ProtoReflect.Descriptor instead.
+func (*CheckKey) Descriptor() ([]byte, []int) {
+	return file_checks_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CheckKey) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+var File_checks_service_proto protoreflect.FileDescriptor
+
+const file_checks_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14checks/service.proto\x12\x06checks\x1a\x12common/types.proto\x1a\x13users/service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x96\x05\n" +
+	"\x05Check\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12#\n" +
+	"\x05value\x18\x03 \x01(\v2\r.checks.ValueR\x05value\x12#\n" +
+	"\acreator\x18\x04 \x01(\v2\t.users.IdR\acreator\x128\n" +
+	"\tcreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12 \n" +
+	"\vactivations\x18\x06 \x01(\x05R\vactivations\x12(\n" +
+	"\x0factivationsLeft\x18\a \x01(\x05R\x0factivationsLeft\x120\n" +
+	"\x05expAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x12 \n" +
+	"\vhasPassword\x18\t \x01(\bR\vhasPassword\x12'\n" +
+	"\trecipient\x18\n" +
+	" \x01(\v2\t.users.IdR\trecipient\x12+\n" +
+	"\x06status\x18\v \x01(\x0e2\x13.checks.CheckStatusR\x06status\x12)\n" +
+	"\n" +
+	"redeemedBy\x18\f \x01(\v2\t.users.IdR\n" +
+	"redeemedBy\x12:\n" +
+	"\n" +
+	"redeemedAt\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"redeemedAt\x126\n" +
+	"\bclosedAt\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12+\n" +
+	"\vcancelledBy\x18\x0f \x01(\v2\t.users.IdR\vcancelledBy\x12%\n" +
+	"\x06bundle\x18\x10 \x03(\v2\r.checks.ValueR\x06bundle\"n\n" +
+	"\x0fCheckActivation\x12\x1d\n" +
+	"\x04user\x18\x01 \x01(\v2\t.users.IdR\x04user\x12<\n" +
+	"\vactivatedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vactivatedAt\"p\n" +
+	"\x0eCheckLifecycle\x12#\n" +
+	"\x05check\x18\x01 \x01(\v2\r.checks.CheckR\x05check\x129\n" +
+	"\vactivations\x18\x02 \x03(\v2\x17.checks.CheckActivationR\vactivations\"\x9c\x01\n" +
+	"\x15CheckLifecycleFailure\x129\n" +
+	"\tlifecycle\x18\x01 \x01(\v2\x16.checks.CheckLifecycleH\x00R\tlifecycle\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\f\n" +
+	"\n" +
+	"_lifecycleB\n" +
+	"\n" +
+	"\b_failure\"\xe8\x02\n" +
+	"\fCheckPreview\x12#\n" +
+	"\x05value\x18\x01 \x01(\v2\r.checks.ValueR\x05value\x12#\n" +
+	"\acreator\x18\x02 \x01(\v2\t.users.IdR\acreator\x12\x18\n" +
+	"\aisValid\x18\x03 \x01(\bR\aisValid\x12\x1b\n" +
+	"\x06reason\x18\x04 \x01(\tH\x00R\x06reason\x88\x01\x01\x12(\n" +
+	"\x0factivationsLeft\x18\x05 \x01(\x05R\x0factivationsLeft\x12 \n" +
+	"\vhasPassword\x18\x06 \x01(\bR\vhasPassword\x120\n" +
+	"\x05expAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x12'\n" +
+	"\trecipient\x18\b \x01(\v2\t.users.IdR\trecipient\x12%\n" +
+	"\x06bundle\x18\t \x03(\v2\r.checks.ValueR\x06bundleB\t\n" +
+	"\a_reason\"\x92\x01\n" +
+	"\x13CheckPreviewFailure\x123\n" +
+	"\apreview\x18\x01 \x01(\v2\x14.checks.CheckPreviewH\x00R\apreview\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\n" +
+	"\n" +
+	"\b_previewB\n" +
+	"\n" +
+	"\b_failure\"H\n" +
+	"\n" +
+	"CheckShare\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\tR\apayload\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x0e\n" +
+	"\x02qr\x18\x03 \x01(\fR\x02qr\"\x88\x01\n" +
+	"\x11CheckShareFailure\x12-\n" +
+	"\x05share\x18\x01 \x01(\v2\x12.checks.CheckShareH\x00R\x05share\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
+	"\x06_shareB\n" +
+	"\n" +
+	"\b_failure\"~\n" +
+	"\fCheckFailure\x12(\n" +
+	"\x05check\x18\x01 \x01(\v2\r.checks.CheckH\x00R\x05check\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
+	"\x06_checkB\n" +
+	"\n" +
+	"\b_failure\"M\n" +
+	"\x05Value\x12,\n" +
+	"\bcurrency\x18\x01 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"X\n" +
+	"\tAllChecks\x12%\n" +
+	"\x06checks\x18\x01 \x03(\v2\r.checks.CheckR\x06checks\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\x9e\x02\n" +
+	"\fChecksFilter\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tpageToken\x18\x03 \x01(\tR\tpageToken\x12 \n" +
+	"\voldestFirst\x18\x04 \x01(\bR\voldestFirst\x121\n" +
+	"\bcurrency\x18\x05 \x01(\x0e2\x10.common.CurrencyH\x00R\bcurrency\x88\x01\x01\x12!\n" +
+	"\tminAmount\x18\x06 \x01(\x03H\x01R\tminAmount\x88\x01\x01\x12!\n" +
+	"\tmaxAmount\x18\a \x01(\x03H\x02R\tmaxAmount\x88\x01\x01B\v\n" +
+	"\t_currencyB\f\n" +
+	"\n" +
+	"_minAmountB\f\n" +
+	"\n" +
+	"_maxAmount\"\x89\x01\n" +
+	"\x10AllChecksFailure\x12.\n" +
+	"\x06checks\x18\x01 \x01(\v2\x11.checks.AllChecksH\x00R\x06checks\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\t\n" +
+	"\a_checksB\n" +
+	"\n" +
+	"\b_failure\"\xa9\x02\n" +
+	"\vCheckCreate\x12#\n" +
+	"\acreator\x18\x01 \x01(\v2\t.users.IdR\acreator\x12#\n" +
+	"\x05value\x18\x02 \x01(\v2\r.checks.ValueR\x05value\x12 \n" +
+	"\vactivations\x18\x03 \x01(\x05R\vactivations\x120\n" +
+	"\x05expAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x12\x1f\n" +
+	"\bpassword\x18\x05 \x01(\tH\x00R\bpassword\x88\x01\x01\x12'\n" +
+	"\trecipient\x18\x06 \x01(\v2\t.users.IdR\trecipient\x12%\n" +
+	"\x06bundle\x18\a \x03(\v2\r.checks.ValueR\x06bundleB\v\n" +
+	"\t_password\"S\n" +
+	"\x10CheckCreateBatch\x12)\n" +
+	"\x05check\x18\x01 \x01(\v2\x13.checks.CheckCreateR\x05check\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\x98\x01\n" +
+	"\bCheckUse\x12!\n" +
+	"\x06userId\x18\x01 \x01(\v2\t.users.IdR\x06userId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1f\n" +
+	"\bpassword\x18\x03 \x01(\tH\x00R\bpassword\x88\x01\x01\x12\x1d\n" +
+	"\apayload\x18\x04 \x01(\tH\x01R\apayload\x88\x01\x01B\v\n" +
+	"\t_passwordB\n" +
+	"\n" +
+	"\b_payload\"\x19\n" +
+	"\aCheckId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"@\n" +
+	"\vCheckCancel\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\x06userId\x18\x02 \x01(\v2\t.users.IdR\x06userId\"E\n" +
+	"\x10CheckLifecycleIn\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12!\n" +
+	"\x06userId\x18\x02 \x01(\v2\t.users.IdR\x06userId\"\x1c\n" +
+	"\bCheckKey\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key*C\n" +
+	"\vCheckStatus\x12\n" +
+	"\n" +
+	"\x06Active\x10\x00\x12\f\n" +
+	"\bRedeemed\x10\x01\x12\r\n" +
+	"\tCancelled\x10\x02\x12\v\n" +
+	"\aExpired\x10\x032\x8e\x04\n" +
 	"\x06Checks\x123\n" +
-	"\x06Create\x12\x13.checks.CheckCreate\x1a\x14.checks.CheckFailure\x12+\n" +
-	"\x06Remove\x12\x0f.checks.CheckId\x1a\x10.common.Response\x12)\n" +
-	"\x03Use\x12\x10.checks.CheckUse\x1a\x10.common.Response\x124\n" +
-	"\rGetUserChecks\x12\t.users.Id\x1a\x18.checks.AllChecksFailureB\n" +
+	"\x06Create\x12\x13.checks.CheckCreate\x1a\x14.checks.CheckFailure\x12A\n" +
+	"\vCreateBatch\x12\x18.checks.CheckCreateBatch\x1a\x18.checks.AllChecksFailure\x12/\n" +
+	"\x06Remove\x12\x13.checks.CheckCancel\x1a\x10.common.Response\x12)\n" +
+	"\x03Use\x12\x10.checks.CheckUse\x1a\x10.common.Response\x12?\n" +
+	"\rGetUserChecks\x12\x14.checks.ChecksFilter\x1a\x18.checks.AllChecksFailure\x126\n" +
+	"\x0fGetChecksToUser\x12\t.users.Id\x1a\x18.checks.AllChecksFailure\x12G\n" +
+	"\fGetLifecycle\x12\x18.checks.CheckLifecycleIn\x1a\x1d.checks.CheckLifecycleFailure\x128\n" +
+	"\aPreview\x12\x10.checks.CheckKey\x1a\x1b.checks.CheckPreviewFailure\x124\n" +
+	"\x05Share\x12\x10.checks.CheckKey\x1a\x19.checks.CheckShareFailureB\n" +
 	"Z\b./checksb\x06proto3"
 
 var (
@@ -467,43 +1532,102 @@ func file_checks_service_proto_rawDescGZIP() []byte {
 	return file_checks_service_proto_rawDescData
 }
 
-var file_checks_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_checks_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_checks_service_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_checks_service_proto_goTypes = []any{
-	(*Check)(nil),                 // 0: checks.Check
-	(*CheckFailure)(nil),          // 1: checks.CheckFailure
-	(*AllChecks)(nil),             // 2: checks.AllChecks
-	(*AllChecksFailure)(nil),      // 3: checks.AllChecksFailure
-	(*CheckCreate)(nil),           // 4: checks.CheckCreate
-	(*CheckUse)(nil),              // 5: checks.CheckUse
-	(*CheckId)(nil),               // 6: checks.CheckId
-	(common.Currency)(0),          // 7: common.Currency
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
-	(*common.Failure)(nil),        // 9: common.Failure
-	(*users.Id)(nil),              // 10: users.Id
-	(*common.Response)(nil),       // 11: common.Response
+	(CheckStatus)(0),              // 0: checks.CheckStatus
+	(*Check)(nil),                 // 1: checks.Check
+	(*CheckActivation)(nil),       // 2: checks.CheckActivation
+	(*CheckLifecycle)(nil),        // 3: checks.CheckLifecycle
+	(*CheckLifecycleFailure)(nil), // 4: checks.CheckLifecycleFailure
+	(*CheckPreview)(nil),          // 5: checks.CheckPreview
+	(*CheckPreviewFailure)(nil),   // 6: checks.CheckPreviewFailure
+	(*CheckShare)(nil),            // 7: checks.CheckShare
+	(*CheckShareFailure)(nil),     // 8: checks.CheckShareFailure
+	(*CheckFailure)(nil),          // 9: checks.CheckFailure
+	(*Value)(nil),                 // 10: checks.Value
+	(*AllChecks)(nil),             // 11: checks.AllChecks
+	(*ChecksFilter)(nil),          // 12: checks.ChecksFilter
+	(*AllChecksFailure)(nil),      // 13: checks.AllChecksFailure
+	(*CheckCreate)(nil),           // 14: checks.CheckCreate
+	(*CheckCreateBatch)(nil),      // 15: checks.CheckCreateBatch
+	(*CheckUse)(nil),              // 16: checks.CheckUse
+	(*CheckId)(nil),               // 17: checks.CheckId
+	(*CheckCancel)(nil),           // 18: checks.CheckCancel
+	(*CheckLifecycleIn)(nil),      // 19: checks.CheckLifecycleIn
+	(*CheckKey)(nil),              // 20: checks.CheckKey
+	(*users.Id)(nil),              // 21: users.Id
+	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
+	(*common.Failure)(nil),        // 23: common.Failure
+	(common.Currency)(0),          // 24: common.Currency
+	(*common.Response)(nil),       // 25: common.Response
 }
 var file_checks_service_proto_depIdxs = []int32{
-	7,  // 0: checks.Check.currency:type_name -> common.Currency
-	8,  // 1: checks.Check.createdAt:type_name -> google.protobuf.Timestamp
-	0,  // 2: checks.CheckFailure.check:type_name -> checks.Check
-	9,  // 3: checks.CheckFailure.failure:type_name -> common.Failure
-	0,  // 4: checks.AllChecks.Checks:type_name -> checks.Check
-	2,  // 5: checks.AllChecksFailure.allChecks:type_name -> checks.AllChecks
-	9,  // 6: checks.AllChecksFailure.failure:type_name -> common.Failure
-	7,  // 7: checks.CheckCreate.currency:type_name -> common.Currency
-	4,  // 8: checks.Checks.Create:input_type -> checks.CheckCreate
-	6,  // 9: checks.Checks.Remove:input_type -> checks.CheckId
-	5,  // 10: checks.Checks.Use:input_type -> checks.CheckUse
-	10, // 11: checks.Checks.GetUserChecks:input_type -> users.Id
-	1,  // 12: checks.Checks.Create:output_type -> checks.CheckFailure
-	11, // 13: checks.Checks.Remove:output_type -> common.Response
-	11, // 14: checks.Checks.Use:output_type -> common.Response
-	3,  // 15: checks.Checks.GetUserChecks:output_type -> checks.AllChecksFailure
-	12, // [12:16] is the sub-list for method output_type
-	8,  // [8:12] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	10, // 0: checks.Check.value:type_name -> checks.Value
+	21, // 1: checks.Check.creator:type_name -> users.Id
+	22, // 2: checks.Check.createdAt:type_name -> google.protobuf.Timestamp
+	22, // 3: checks.Check.expAt:type_name -> google.protobuf.Timestamp
+	21, // 4: checks.Check.recipient:type_name -> users.Id
+	0,  // 5: checks.Check.status:type_name -> checks.CheckStatus
+	21, // 6: checks.Check.redeemedBy:type_name -> users.Id
+	22, // 7: checks.Check.redeemedAt:type_name -> google.protobuf.Timestamp
+	22, // 8: checks.Check.closedAt:type_name -> google.protobuf.Timestamp
+	21, // 9: checks.Check.cancelledBy:type_name -> users.Id
+	10, // 10: checks.Check.bundle:type_name -> checks.Value
+	21, // 11: checks.CheckActivation.user:type_name -> users.Id
+	22, // 12: checks.CheckActivation.activatedAt:type_name -> google.protobuf.Timestamp
+	1,  // 13: checks.CheckLifecycle.check:type_name -> checks.Check
+	2,  // 14: checks.CheckLifecycle.activations:type_name -> checks.CheckActivation
+	3,  // 15: checks.CheckLifecycleFailure.lifecycle:type_name -> checks.CheckLifecycle
+	23, // 16: checks.CheckLifecycleFailure.failure:type_name -> common.Failure
+	10, // 17: checks.CheckPreview.value:type_name -> checks.Value
+	21, // 18: checks.CheckPreview.creator:type_name -> users.Id
+	22, // 19: checks.CheckPreview.expAt:type_name -> google.protobuf.Timestamp
+	21, // 20: checks.CheckPreview.recipient:type_name -> users.Id
+	10, // 21: checks.CheckPreview.bundle:type_name -> checks.Value
+	5,  // 22: checks.CheckPreviewFailure.preview:type_name -> checks.CheckPreview
+	23, // 23: checks.CheckPreviewFailure.failure:type_name -> common.Failure
+	7,  // 24: checks.CheckShareFailure.share:type_name -> checks.CheckShare
+	23, // 25: checks.CheckShareFailure.failure:type_name -> common.Failure
+	1,  // 26: checks.CheckFailure.check:type_name -> checks.Check
+	23, // 27: checks.CheckFailure.failure:type_name -> common.Failure
+	24, // 28: checks.Value.currency:type_name -> common.Currency
+	1,  // 29: checks.AllChecks.checks:type_name -> checks.Check
+	24, // 30: checks.ChecksFilter.currency:type_name -> common.Currency
+	11, // 31: checks.AllChecksFailure.checks:type_name -> checks.AllChecks
+	23, // 32: checks.AllChecksFailure.failure:type_name -> common.Failure
+	21, // 33: checks.CheckCreate.creator:type_name -> users.Id
+	10, // 34: checks.CheckCreate.value:type_name -> checks.Value
+	22, // 35: checks.CheckCreate.expAt:type_name -> google.protobuf.Timestamp
+	21, // 36: checks.CheckCreate.recipient:type_name -> users.Id
+	10, // 37: checks.CheckCreate.bundle:type_name -> checks.Value
+	14, // 38: checks.CheckCreateBatch.check:type_name -> checks.CheckCreate
+	21, // 39: checks.CheckUse.userId:type_name -> users.Id
+	21, // 40: checks.CheckCancel.userId:type_name -> users.Id
+	21, // 41: checks.CheckLifecycleIn.userId:type_name -> users.Id
+	14, // 42: checks.Checks.Create:input_type -> checks.CheckCreate
+	15, // 43: checks.Checks.CreateBatch:input_type -> checks.CheckCreateBatch
+	18, // 44: checks.Checks.Remove:input_type -> checks.CheckCancel
+	16, // 45: checks.Checks.Use:input_type -> checks.CheckUse
+	12, // 46: checks.Checks.GetUserChecks:input_type -> checks.ChecksFilter
+	21, // 47: checks.Checks.GetChecksToUser:input_type -> users.Id
+	19, // 48: checks.Checks.GetLifecycle:input_type -> checks.CheckLifecycleIn
+	20, // 49: checks.Checks.Preview:input_type -> checks.CheckKey
+	20, // 50: checks.Checks.Share:input_type -> checks.CheckKey
+	9,  // 51: checks.Checks.Create:output_type -> checks.CheckFailure
+	13, // 52: checks.Checks.CreateBatch:output_type -> checks.AllChecksFailure
+	25, // 53: checks.Checks.Remove:output_type -> common.Response
+	25, // 54: checks.Checks.Use:output_type -> common.Response
+	13, // 55: checks.Checks.GetUserChecks:output_type -> checks.AllChecksFailure
+	13, // 56: checks.Checks.GetChecksToUser:output_type -> checks.AllChecksFailure
+	4,  // 57: checks.Checks.GetLifecycle:output_type -> checks.CheckLifecycleFailure
+	6,  // 58: checks.Checks.Preview:output_type -> checks.CheckPreviewFailure
+	8,  // 59: checks.Checks.Share:output_type -> checks.CheckShareFailure
+	51, // [51:60] is the sub-list for method output_type
+	42, // [42:51] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_checks_service_proto_init() }
@@ -511,20 +1635,28 @@ func file_checks_service_proto_init() {
 	if File_checks_service_proto != nil {
 		return
 	}
-	file_checks_service_proto_msgTypes[1].OneofWrappers = []any{}
 	file_checks_service_proto_msgTypes[3].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[4].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[7].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[8].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[11].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[12].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[13].OneofWrappers = []any{}
+	file_checks_service_proto_msgTypes[15].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_checks_service_proto_rawDesc), len(file_checks_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   7,
+			NumEnums:      1,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_checks_service_proto_goTypes,
 		DependencyIndexes: file_checks_service_proto_depIdxs,
+		EnumInfos:         file_checks_service_proto_enumTypes,
 		MessageInfos:      file_checks_service_proto_msgTypes,
 	}.Build()
 	File_checks_service_proto = out.File
@@ -7,12 +7,12 @@
 package checks
 
 import (
-	common "protobuf/common"
-	users "protobuf/users"
 	context "context"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	common "protobuf/common"
+	users "protobuf/users"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -21,10 +21,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Checks_Create_FullMethodName        = "/checks.Checks/Create"
-	Checks_Remove_FullMethodName        = "/checks.Checks/Remove"
-	Checks_Use_FullMethodName           = "/checks.Checks/Use"
-	Checks_GetUserChecks_FullMethodName = "/checks.Checks/GetUserChecks"
+	Checks_Create_FullMethodName          = "/checks.Checks/Create"
+	Checks_CreateBatch_FullMethodName     = "/checks.Checks/CreateBatch"
+	Checks_Remove_FullMethodName          = "/checks.Checks/Remove"
+	Checks_Use_FullMethodName             = "/checks.Checks/Use"
+	Checks_GetUserChecks_FullMethodName   = "/checks.Checks/GetUserChecks"
+	Checks_GetChecksToUser_FullMethodName = "/checks.Checks/GetChecksToUser"
+	Checks_GetLifecycle_FullMethodName    = "/checks.Checks/GetLifecycle"
+	Checks_Preview_FullMethodName         = "/checks.Checks/Preview"
+	Checks_Share_FullMethodName           = "/checks.Checks/Share"
 )
 
 // ChecksClient is the client API for Checks service.
@@ -33,12 +38,22 @@ const (
 type ChecksClient interface {
 	// Create check
 	Create(ctx context.Context, in *CheckCreate, opts ...grpc.CallOption) (*CheckFailure, error)
-	// Make check used
-	Remove(ctx context.Context, in *CheckId, opts ...grpc.CallOption) (*common.Response, error)
+	// Create several same checks, creator pays once for all
+	CreateBatch(ctx context.Context, in *CheckCreateBatch, opts ...grpc.CallOption) (*AllChecksFailure, error)
+	// Cancel check by creator or moderator, unused value returns to creator
+	Remove(ctx context.Context, in *CheckCancel, opts ...grpc.CallOption) (*common.Response, error)
 	// Use check
 	Use(ctx context.Context, in *CheckUse, opts ...grpc.CallOption) (*common.Response, error)
-	// Get check created by user
-	GetUserChecks(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*AllChecksFailure, error)
+	// Get page of checks created by user
+	GetUserChecks(ctx context.Context, in *ChecksFilter, opts ...grpc.CallOption) (*AllChecksFailure, error)
+	// Get checks addressed to user
+	GetChecksToUser(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*AllChecksFailure, error)
+	// Get check with all activations by id, only for creator of check or moderator
+	GetLifecycle(ctx context.Context, in *CheckLifecycleIn, opts ...grpc.CallOption) (*CheckLifecycleFailure, error)
+	// Get value and validity of check by key without using it
+	Preview(ctx context.Context, in *CheckKey, opts ...grpc.CallOption) (*CheckPreviewFailure, error)
+	// Get signed payload of check, deep link and QR code with it
+	Share(ctx context.Context, in *CheckKey, opts ...grpc.CallOption) (*CheckShareFailure, error)
 }
 
 type checksClient struct {
@@ -59,7 +74,17 @@ func (c *checksClient) Create(ctx context.Context, in *CheckCreate, opts ...grpc
 	return out, nil
 }
 
-func (c *checksClient) Remove(ctx context.Context, in *CheckId, opts ...grpc.CallOption) (*common.Response, error) {
+func (c *checksClient) CreateBatch(ctx context.Context, in *CheckCreateBatch, opts ...grpc.CallOption) (*AllChecksFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllChecksFailure)
+	err := c.cc.Invoke(ctx, Checks_CreateBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checksClient) Remove(ctx context.Context, in *CheckCancel, opts ...grpc.CallOption) (*common.Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(common.Response)
 	err := c.cc.Invoke(ctx, Checks_Remove_FullMethodName, in, out, cOpts...)
@@ -79,7 +104,7 @@ func (c *checksClient) Use(ctx context.Context, in *CheckUse, opts ...grpc.CallO
 	return out, nil
 }
 
-func (c *checksClient) GetUserChecks(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*AllChecksFailure, error) {
+func (c *checksClient) GetUserChecks(ctx context.Context, in *ChecksFilter, opts ...grpc.CallOption) (*AllChecksFailure, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AllChecksFailure)
 	err := c.cc.Invoke(ctx, Checks_GetUserChecks_FullMethodName, in, out, cOpts...)
@@ -89,18 +114,68 @@ func (c *checksClient) GetUserChecks(ctx context.Context, in *users.Id, opts ...
 	return out, nil
 }
 
+func (c *checksClient) GetChecksToUser(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*AllChecksFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AllChecksFailure)
+	err := c.cc.Invoke(ctx, Checks_GetChecksToUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checksClient) GetLifecycle(ctx context.Context, in *CheckLifecycleIn, opts ...grpc.CallOption) (*CheckLifecycleFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckLifecycleFailure)
+	err := c.cc.Invoke(ctx, Checks_GetLifecycle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checksClient) Preview(ctx context.Context, in *CheckKey, opts ...grpc.CallOption) (*CheckPreviewFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckPreviewFailure)
+	err := c.cc.Invoke(ctx, Checks_Preview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checksClient) Share(ctx context.Context, in *CheckKey, opts ...grpc.CallOption) (*CheckShareFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckShareFailure)
+	err := c.cc.Invoke(ctx, Checks_Share_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChecksServer is the server API for Checks service.
 // All implementations must embed UnimplementedChecksServer
 // for forward compatibility.
 type ChecksServer interface {
 	// Create check
 	Create(context.Context, *CheckCreate) (*CheckFailure, error)
-	// Make check used
-	Remove(context.Context, *CheckId) (*common.Response, error)
+	// Create several same checks, creator pays once for all
+	CreateBatch(context.Context, *CheckCreateBatch) (*AllChecksFailure, error)
+	// Cancel check by creator or moderator, unused value returns to creator
+	Remove(context.Context, *CheckCancel) (*common.Response, error)
 	// Use check
 	Use(context.Context, *CheckUse) (*common.Response, error)
-	// Get check created by user
-	GetUserChecks(context.Context, *users.Id) (*AllChecksFailure, error)
+	// Get page of checks created by user
+	GetUserChecks(context.Context, *ChecksFilter) (*AllChecksFailure, error)
+	// Get checks addressed to user
+	GetChecksToUser(context.Context, *users.Id) (*AllChecksFailure, error)
+	// Get check with all activations by id, only for creator of check or moderator
+	GetLifecycle(context.Context, *CheckLifecycleIn) (*CheckLifecycleFailure, error)
+	// Get value and validity of check by key without using it
+	Preview(context.Context, *CheckKey) (*CheckPreviewFailure, error)
+	// Get signed payload of check, deep link and QR code with it
+	Share(context.Context, *CheckKey) (*CheckShareFailure, error)
 	mustEmbedUnimplementedChecksServer()
 }
 
@@ -114,15 +189,30 @@ type UnimplementedChecksServer struct{}
 func (UnimplementedChecksServer) Create(context.Context, *CheckCreate) (*CheckFailure, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
-func (UnimplementedChecksServer) Remove(context.Context, *CheckId) (*common.Response, error) {
+func (UnimplementedChecksServer) CreateBatch(context.Context, *CheckCreateBatch) (*AllChecksFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBatch not implemented")
+}
+func (UnimplementedChecksServer) Remove(context.Context, *CheckCancel) (*common.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Remove not implemented")
 }
 func (UnimplementedChecksServer) Use(context.Context, *CheckUse) (*common.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Use not implemented")
 }
-func (UnimplementedChecksServer) GetUserChecks(context.Context, *users.Id) (*AllChecksFailure, error) {
+func (UnimplementedChecksServer) GetUserChecks(context.Context, *ChecksFilter) (*AllChecksFailure, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserChecks not implemented")
 }
+func (UnimplementedChecksServer) GetChecksToUser(context.Context, *users.Id) (*AllChecksFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChecksToUser not implemented")
+}
+func (UnimplementedChecksServer) GetLifecycle(context.Context, *CheckLifecycleIn) (*CheckLifecycleFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLifecycle not implemented")
+}
+func (UnimplementedChecksServer) Preview(context.Context, *CheckKey) (*CheckPreviewFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Preview not implemented")
+}
+func (UnimplementedChecksServer) Share(context.Context, *CheckKey) (*CheckShareFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Share not implemented")
+}
 func (UnimplementedChecksServer) mustEmbedUnimplementedChecksServer() {}
 func (UnimplementedChecksServer) testEmbeddedByValue()                {}
 
@@ -162,8 +252,26 @@ func _Checks_Create_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Checks_CreateBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCreateBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecksServer).CreateBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Checks_CreateBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecksServer).CreateBatch(ctx, req.(*CheckCreateBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Checks_Remove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CheckId)
+	in := new(CheckCancel)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -175,7 +283,7 @@ func _Checks_Remove_Handler(srv interface{}, ctx context.Context, dec func(inter
 		FullMethod: Checks_Remove_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChecksServer).Remove(ctx, req.(*CheckId))
+		return srv.(ChecksServer).Remove(ctx, req.(*CheckCancel))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -199,7 +307,7 @@ func _Checks_Use_Handler(srv interface{}, ctx context.Context, dec func(interfac
 }
 
 func _Checks_GetUserChecks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(users.Id)
+	in := new(ChecksFilter)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -211,7 +319,79 @@ func _Checks_GetUserChecks_Handler(srv interface{}, ctx context.Context, dec fun
 		FullMethod: Checks_GetUserChecks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ChecksServer).GetUserChecks(ctx, req.(*users.Id))
+		return srv.(ChecksServer).GetUserChecks(ctx, req.(*ChecksFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Checks_GetChecksToUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(users.Id)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecksServer).GetChecksToUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Checks_GetChecksToUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecksServer).GetChecksToUser(ctx, req.(*users.Id))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Checks_GetLifecycle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckLifecycleIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecksServer).GetLifecycle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Checks_GetLifecycle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecksServer).GetLifecycle(ctx, req.(*CheckLifecycleIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Checks_Preview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecksServer).Preview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Checks_Preview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecksServer).Preview(ctx, req.(*CheckKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Checks_Share_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChecksServer).Share(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Checks_Share_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChecksServer).Share(ctx, req.(*CheckKey))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -227,6 +407,10 @@ var Checks_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Create",
 			Handler:    _Checks_Create_Handler,
 		},
+		{
+			MethodName: "CreateBatch",
+			Handler:    _Checks_CreateBatch_Handler,
+		},
 		{
 			MethodName: "Remove",
 			Handler:    _Checks_Remove_Handler,
@@ -239,6 +423,22 @@ var Checks_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUserChecks",
 			Handler:    _Checks_GetUserChecks_Handler,
 		},
+		{
+			MethodName: "GetChecksToUser",
+			Handler:    _Checks_GetChecksToUser_Handler,
+		},
+		{
+			MethodName: "GetLifecycle",
+			Handler:    _Checks_GetLifecycle_Handler,
+		},
+		{
+			MethodName: "Preview",
+			Handler:    _Checks_Preview_Handler,
+		},
+		{
+			MethodName: "Share",
+			Handler:    _Checks_Share_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "checks/service.proto",
@@ -7,11 +7,12 @@
 package promos
 
 import (
-	common "protobuf/common"
-	users "protobuf/users"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	common "protobuf/common"
+	users "protobuf/users"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,10 +25,208 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type PromoState int32
+
+const (
+	PromoState_AnyState  PromoState = 0
+	PromoState_Active    PromoState = 1 // Not paused, started, not expired and has uses
+	PromoState_Expired   PromoState = 2
+	PromoState_Exhausted PromoState = 3 // No uses left
+	PromoState_Scheduled PromoState = 4 // Not started yet
+	PromoState_Paused    PromoState = 5
+)
+
+// Enum value maps for PromoState.
+var (
+	PromoState_name = map[int32]string{
+		0: "AnyState",
+		1: "Active",
+		2: "Expired",
+		3: "Exhausted",
+		4: "Scheduled",
+		5: "Paused",
+	}
+	PromoState_value = map[string]int32{
+		"AnyState":  0,
+		"Active":    1,
+		"Expired":   2,
+		"Exhausted": 3,
+		"Scheduled": 4,
+		"Paused":    5,
+	}
+)
+
+func (x PromoState) Enum() *PromoState {
+	p := new(PromoState)
+	*p = x
+	return p
+}
+
+func (x PromoState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PromoState) Descriptor() protoreflect.EnumDescriptor {
+	return file_promos_service_proto_enumTypes[0].Descriptor()
+}
+
+func (PromoState) Type() protoreflect.EnumType {
+	return &file_promos_service_proto_enumTypes[0]
+}
+
+func (x PromoState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PromoState.Descriptor instead.
+func (PromoState) EnumDescriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{0}
+}
+
+type PromoOrder int32
+
+const (
+	PromoOrder_ByCreatedAt PromoOrder = 0
+	PromoOrder_ByExpAt     PromoOrder = 1
+)
+
+// Enum value maps for PromoOrder.
+var (
+	PromoOrder_name = map[int32]string{
+		0: "ByCreatedAt",
+		1: "ByExpAt",
+	}
+	PromoOrder_value = map[string]int32{
+		"ByCreatedAt": 0,
+		"ByExpAt":     1,
+	}
+)
+
+func (x PromoOrder) Enum() *PromoOrder {
+	p := new(PromoOrder)
+	*p = x
+	return p
+}
+
+func (x PromoOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PromoOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_promos_service_proto_enumTypes[1].Descriptor()
+}
+
+func (PromoOrder) Type() protoreflect.EnumType {
+	return &file_promos_service_proto_enumTypes[1]
+}
+
+func (x PromoOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PromoOrder.Descriptor instead.
+func (PromoOrder) EnumDescriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{1}
+}
+
+type StatsBucket int32
+
+const (
+	StatsBucket_Day  StatsBucket = 0
+	StatsBucket_Hour StatsBucket = 1
+)
+
+// Enum value maps for StatsBucket.
+var (
+	StatsBucket_name = map[int32]string{
+		0: "Day",
+		1: "Hour",
+	}
+	StatsBucket_value = map[string]int32{
+		"Day":  0,
+		"Hour": 1,
+	}
+)
+
+func (x StatsBucket) Enum() *StatsBucket {
+	p := new(StatsBucket)
+	*p = x
+	return p
+}
+
+func (x StatsBucket) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsBucket) Descriptor() protoreflect.EnumDescriptor {
+	return file_promos_service_proto_enumTypes[2].Descriptor()
+}
+
+func (StatsBucket) Type() protoreflect.EnumType {
+	return &file_promos_service_proto_enumTypes[2]
+}
+
+func (x StatsBucket) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsBucket.Descriptor instead.
+func (StatsBucket) EnumDescriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{2}
+}
+
+type FileFormat int32
+
+const (
+	FileFormat_CSV  FileFormat = 0 // First row is header with names of columns
+	FileFormat_JSON FileFormat = 1 // Array of objects
+)
+
+// Enum value maps for FileFormat.
+var (
+	FileFormat_name = map[int32]string{
+		0: "CSV",
+		1: "JSON",
+	}
+	FileFormat_value = map[string]int32{
+		"CSV":  0,
+		"JSON": 1,
+	}
+)
+
+func (x FileFormat) Enum() *FileFormat {
+	p := new(FileFormat)
+	*p = x
+	return p
+}
+
+func (x FileFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_promos_service_proto_enumTypes[3].Descriptor()
+}
+
+func (FileFormat) Type() protoreflect.EnumType {
+	return &file_promos_service_proto_enumTypes[3]
+}
+
+func (x FileFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileFormat.Descriptor instead.
+func (FileFormat) EnumDescriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{3}
+}
+
 type AddTimeIn struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PromoId       int64                  `protobuf:"varint,1,opt,name=promoId,proto3" json:"promoId,omitempty"`
-	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expAt,proto3" json:"expAt,omitempty"`
+	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expAt,proto3" json:"expAt,omitempty"`                  // Optional, not changed if empty
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=startsAt,proto3" json:"startsAt,omitempty"`            // Optional, not changed if empty, must be before expAt
+	ClearStartsAt bool                   `protobuf:"varint,4,opt,name=clearStartsAt,proto3" json:"clearStartsAt,omitempty"` // Optional, promo can be used right away, startsAt must be empty
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -76,6 +275,20 @@ func (x *AddTimeIn) GetExpAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AddTimeIn) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *AddTimeIn) GetClearStartsAt() bool {
+	if x != nil {
+		return x.ClearStartsAt
+	}
+	return false
+}
+
 type AddUsesIn struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PromoId       int64                  `protobuf:"varint,1,opt,name=promoId,proto3" json:"promoId,omitempty"`
@@ -217,17 +430,28 @@ func (x *PromoId) GetId() int64 {
 }
 
 type PromoCode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Currency      common.Currency        `protobuf:"varint,3,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
-	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Uses          int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`
-	Creator       int64                  `protobuf:"varint,6,opt,name=creator,proto3" json:"creator,omitempty"`
-	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expAt,proto3" json:"expAt,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Currency       common.Currency        `protobuf:"varint,3,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Uses           int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`
+	Creator        int64                  `protobuf:"varint,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	ExpAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expAt,proto3" json:"expAt,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	StartsAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=startsAt,proto3" json:"startsAt,omitempty"`               // Empty if promo can be used right after creating
+	IsActive       bool                   `protobuf:"varint,10,opt,name=isActive,proto3" json:"isActive,omitempty"`             // False if promo paused
+	CampaignId     int64                  `protobuf:"varint,11,opt,name=campaignId,proto3" json:"campaignId,omitempty"`         // Zero if promo is not generated code of campaign
+	Audience       *Audience              `protobuf:"bytes,12,opt,name=audience,proto3" json:"audience,omitempty"`              // Empty if promo for any user
+	Referrer       int64                  `protobuf:"varint,13,opt,name=referrer,proto3" json:"referrer,omitempty"`             // Owner of referral code, zero if promo is not referral code
+	ReferrerAmount int64                  `protobuf:"varint,14,opt,name=referrerAmount,proto3" json:"referrerAmount,omitempty"` // Reward of owner for every new user, in currency of promo
+	UserLimit      int32                  `protobuf:"varint,15,opt,name=userLimit,proto3" json:"userLimit,omitempty"`           // Activations by one user, -1 for unlimited
+	Cooldown       *durationpb.Duration   `protobuf:"bytes,16,opt,name=cooldown,proto3" json:"cooldown,omitempty"`              // Time between activations by one user, empty if none
+	Rewards        *RewardTable           `protobuf:"bytes,17,opt,name=rewards,proto3" json:"rewards,omitempty"`                // Empty if promo pays amount in currency of promo
+	DeletedAt      *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=deletedAt,proto3" json:"deletedAt,omitempty"`            // Empty if promo is not deleted
+	DeletedBy      int64                  `protobuf:"varint,19,opt,name=deletedBy,proto3" json:"deletedBy,omitempty"`           // User, who deleted promo
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PromoCode) Reset() {
@@ -316,6 +540,83 @@ func (x *PromoCode) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PromoCode) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *PromoCode) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *PromoCode) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *PromoCode) GetAudience() *Audience {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+func (x *PromoCode) GetReferrer() int64 {
+	if x != nil {
+		return x.Referrer
+	}
+	return 0
+}
+
+func (x *PromoCode) GetReferrerAmount() int64 {
+	if x != nil {
+		return x.ReferrerAmount
+	}
+	return 0
+}
+
+func (x *PromoCode) GetUserLimit() int32 {
+	if x != nil {
+		return x.UserLimit
+	}
+	return 0
+}
+
+func (x *PromoCode) GetCooldown() *durationpb.Duration {
+	if x != nil {
+		return x.Cooldown
+	}
+	return nil
+}
+
+func (x *PromoCode) GetRewards() *RewardTable {
+	if x != nil {
+		return x.Rewards
+	}
+	return nil
+}
+
+func (x *PromoCode) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *PromoCode) GetDeletedBy() int64 {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return 0
+}
+
 type CreatePromo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -324,6 +625,11 @@ type CreatePromo struct {
 	Creator       int64                  `protobuf:"varint,4,opt,name=creator,proto3" json:"creator,omitempty"`
 	Uses          int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`
 	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expAt,proto3" json:"expAt,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=startsAt,proto3" json:"startsAt,omitempty"`    // Optional, promo can't be used before it
+	Audience      *Audience              `protobuf:"bytes,8,opt,name=audience,proto3" json:"audience,omitempty"`    // Optional, only these users can use promo
+	UserLimit     int32                  `protobuf:"varint,9,opt,name=userLimit,proto3" json:"userLimit,omitempty"` // Optional, activations by one user (1 by default), -1 for unlimited
+	Cooldown      *durationpb.Duration   `protobuf:"bytes,10,opt,name=cooldown,proto3" json:"cooldown,omitempty"`   // Optional, time between activations by one user
+	Rewards       *RewardTable           `protobuf:"bytes,11,opt,name=rewards,proto3" json:"rewards,omitempty"`     // Optional, instead of amount in currency of promo
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -400,28 +706,66 @@ func (x *CreatePromo) GetExpAt() *timestamppb.Timestamp {
 	return nil
 }
 
-type PromoFailure struct {
+func (x *CreatePromo) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreatePromo) GetAudience() *Audience {
+	if x != nil {
+		return x.Audience
+	}
+	return nil
+}
+
+func (x *CreatePromo) GetUserLimit() int32 {
+	if x != nil {
+		return x.UserLimit
+	}
+	return 0
+}
+
+func (x *CreatePromo) GetCooldown() *durationpb.Duration {
+	if x != nil {
+		return x.Cooldown
+	}
+	return nil
+}
+
+func (x *CreatePromo) GetRewards() *RewardTable {
+	if x != nil {
+		return x.Rewards
+	}
+	return nil
+}
+
+// Reward of activation chosen by table:
+// first tier with upTo not less than number of activation, else weighted random draw among options,
+// else amount in currency of promo
+type RewardTable struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PromoCode     *PromoCode             `protobuf:"bytes,1,opt,name=promoCode,proto3,oneof" json:"promoCode,omitempty"`
-	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	Tiers         []*RewardTier          `protobuf:"bytes,1,rep,name=tiers,proto3" json:"tiers,omitempty"` // Ordered by upTo
+	Options       []*RewardOption        `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromoFailure) Reset() {
-	*x = PromoFailure{}
+func (x *RewardTable) Reset() {
+	*x = RewardTable{}
 	mi := &file_promos_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromoFailure) String() string {
+func (x *RewardTable) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromoFailure) ProtoMessage() {}
+func (*RewardTable) ProtoMessage() {}
 
-func (x *PromoFailure) ProtoReflect() protoreflect.Message {
+func (x *RewardTable) ProtoReflect() protoreflect.Message {
 	mi := &file_promos_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -433,47 +777,48 @@ func (x *PromoFailure) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromoFailure.ProtoReflect.Descriptor instead.
-func (*PromoFailure) Descriptor() ([]byte, []int) {
+// Deprecated: Use RewardTable.ProtoReflect.Descriptor instead.
+func (*RewardTable) Descriptor() ([]byte, []int) {
 	return file_promos_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PromoFailure) GetPromoCode() *PromoCode {
+func (x *RewardTable) GetTiers() []*RewardTier {
 	if x != nil {
-		return x.PromoCode
+		return x.Tiers
 	}
 	return nil
 }
 
-func (x *PromoFailure) GetFailure() *common.Failure {
+func (x *RewardTable) GetOptions() []*RewardOption {
 	if x != nil {
-		return x.Failure
+		return x.Options
 	}
 	return nil
 }
 
-type PromoUserId struct {
+type RewardTier struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
-	PromoId       int64                  `protobuf:"varint,2,opt,name=promoId,proto3" json:"promoId,omitempty"`
+	UpTo          int64                  `protobuf:"varint,1,opt,name=upTo,proto3" json:"upTo,omitempty"` // Reward for activators from previous tier up to this number
+	Currency      common.Currency        `protobuf:"varint,2,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PromoUserId) Reset() {
-	*x = PromoUserId{}
+func (x *RewardTier) Reset() {
+	*x = RewardTier{}
 	mi := &file_promos_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PromoUserId) String() string {
+func (x *RewardTier) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PromoUserId) ProtoMessage() {}
+func (*RewardTier) ProtoMessage() {}
 
-func (x *PromoUserId) ProtoReflect() protoreflect.Message {
+func (x *RewardTier) ProtoReflect() protoreflect.Message {
 	mi := &file_promos_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -485,59 +830,2413 @@ func (x *PromoUserId) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PromoUserId.ProtoReflect.Descriptor instead.
-func (*PromoUserId) Descriptor() ([]byte, []int) {
+// Deprecated: Use RewardTier.ProtoReflect.Descriptor instead.
+func (*RewardTier) Descriptor() ([]byte, []int) {
 	return file_promos_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *PromoUserId) GetUserId() int64 {
+func (x *RewardTier) GetUpTo() int64 {
 	if x != nil {
-		return x.UserId
+		return x.UpTo
 	}
 	return 0
 }
 
-func (x *PromoUserId) GetPromoId() int64 {
+func (x *RewardTier) GetCurrency() common.Currency {
 	if x != nil {
-		return x.PromoId
+		return x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *RewardTier) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
 	}
 	return 0
 }
 
-var File_promos_service_proto protoreflect.FileDescriptor
+type RewardOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Weight        int32                  `protobuf:"varint,1,opt,name=weight,proto3" json:"weight,omitempty"` // Chance of option is weight divided by sum of weights
+	Currency      common.Currency        `protobuf:"varint,2,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_promos_service_proto_rawDesc = "" +
-	"\n" +
-	"\x14promos/service.proto\x12\n" +
-	"promocodes\x1a\x12common/types.proto\x1a\x13users/service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"W\n" +
-	"\tAddTimeIn\x12\x18\n" +
-	"\apromoId\x18\x01 \x01(\x03R\apromoId\x120\n" +
-	"\x05expAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\"9\n" +
-	"\tAddUsesIn\x12\x18\n" +
-	"\apromoId\x18\x01 \x01(\x03R\apromoId\x12\x12\n" +
-	"\x04uses\x18\x02 \x01(\x05R\x04uses\"\x1f\n" +
-	"\tPromoName\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\"\x19\n" +
-	"\aPromoId\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x8f\x02\n" +
-	"\tPromoCode\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
-	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x12\n" +
-	"\x04uses\x18\x05 \x01(\x05R\x04uses\x12\x18\n" +
-	"\acreator\x18\x06 \x01(\x03R\acreator\x120\n" +
-	"\x05expAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x128\n" +
-	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc7\x01\n" +
-	"\vCreatePromo\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12,\n" +
-	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x18\n" +
-	"\acreator\x18\x04 \x01(\x03R\acreator\x12\x12\n" +
-	"\x04uses\x18\x05 \x01(\x05R\x04uses\x120\n" +
-	"\x05expAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\"\x92\x01\n" +
-	"\fPromoFailure\x128\n" +
-	"\tpromoCode\x18\x01 \x01(\v2\x15.promocodes.PromoCodeH\x00R\tpromoCode\x88\x01\x01\x12.\n" +
+func (x *RewardOption) Reset() {
+	*x = RewardOption{}
+	mi := &file_promos_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardOption) ProtoMessage() {}
+
+func (x *RewardOption) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardOption.ProtoReflect.Descriptor instead.
+func (*RewardOption) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RewardOption) GetWeight() int32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *RewardOption) GetCurrency() common.Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *RewardOption) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Reward struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Currency      common.Currency        `protobuf:"varint,1,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Reward) Reset() {
+	*x = Reward{}
+	mi := &file_promos_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Reward) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Reward) ProtoMessage() {}
+
+func (x *Reward) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Reward.ProtoReflect.Descriptor instead.
+func (*Reward) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Reward) GetCurrency() common.Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *Reward) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type RewardFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reward        *Reward                `protobuf:"bytes,1,opt,name=reward,proto3,oneof" json:"reward,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RewardFailure) Reset() {
+	*x = RewardFailure{}
+	mi := &file_promos_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RewardFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RewardFailure) ProtoMessage() {}
+
+func (x *RewardFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RewardFailure.ProtoReflect.Descriptor instead.
+func (*RewardFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RewardFailure) GetReward() *Reward {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+func (x *RewardFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+// User must match every set condition
+type Audience struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roles         []users.Role           `protobuf:"varint,1,rep,packed,name=roles,proto3,enum=users.Role" json:"roles,omitempty"` // Empty for any role
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=createdAfter,proto3" json:"createdAfter,omitempty"`           // Optional, for accounts created after it
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdBefore,proto3" json:"createdBefore,omitempty"`         // Optional, for accounts created before it
+	Users         []int64                `protobuf:"varint,4,rep,packed,name=users,proto3" json:"users,omitempty"`                 // Allowlist, empty for any user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Audience) Reset() {
+	*x = Audience{}
+	mi := &file_promos_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Audience) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Audience) ProtoMessage() {}
+
+func (x *Audience) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Audience.ProtoReflect.Descriptor instead.
+func (*Audience) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Audience) GetRoles() []users.Role {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Audience) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *Audience) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *Audience) GetUsers() []int64 {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type PromoFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoCode     *PromoCode             `protobuf:"bytes,1,opt,name=promoCode,proto3,oneof" json:"promoCode,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoFailure) Reset() {
+	*x = PromoFailure{}
+	mi := &file_promos_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoFailure) ProtoMessage() {}
+
+func (x *PromoFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoFailure.ProtoReflect.Descriptor instead.
+func (*PromoFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PromoFailure) GetPromoCode() *PromoCode {
+	if x != nil {
+		return x.PromoCode
+	}
+	return nil
+}
+
+func (x *PromoFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type PromoUserId struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	PromoId       int64                  `protobuf:"varint,2,opt,name=promoId,proto3" json:"promoId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoUserId) Reset() {
+	*x = PromoUserId{}
+	mi := &file_promos_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoUserId) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoUserId) ProtoMessage() {}
+
+func (x *PromoUserId) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoUserId.ProtoReflect.Descriptor instead.
+func (*PromoUserId) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PromoUserId) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PromoUserId) GetPromoId() int64 {
+	if x != nil {
+		return x.PromoId
+	}
+	return 0
+}
+
+type PromoUserName struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoUserName) Reset() {
+	*x = PromoUserName{}
+	mi := &file_promos_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoUserName) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoUserName) ProtoMessage() {}
+
+func (x *PromoUserName) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoUserName.ProtoReflect.Descriptor instead.
+func (*PromoUserName) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PromoUserName) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PromoUserName) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type PromoFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`        // Size of page, 0 is default size
+	PageToken     string                 `protobuf:"bytes,2,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // Empty for first page
+	State         PromoState             `protobuf:"varint,3,opt,name=state,proto3,enum=promocodes.PromoState" json:"state,omitempty"`
+	Creator       *int64                 `protobuf:"varint,4,opt,name=creator,proto3,oneof" json:"creator,omitempty"`
+	Currency      *common.Currency       `protobuf:"varint,5,opt,name=currency,proto3,enum=common.Currency,oneof" json:"currency,omitempty"`
+	NamePrefix    string                 `protobuf:"bytes,6,opt,name=namePrefix,proto3" json:"namePrefix,omitempty"`
+	Order         PromoOrder             `protobuf:"varint,7,opt,name=order,proto3,enum=promocodes.PromoOrder" json:"order,omitempty"`
+	Descending    bool                   `protobuf:"varint,8,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoFilter) Reset() {
+	*x = PromoFilter{}
+	mi := &file_promos_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoFilter) ProtoMessage() {}
+
+func (x *PromoFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoFilter.ProtoReflect.Descriptor instead.
+func (*PromoFilter) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PromoFilter) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PromoFilter) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *PromoFilter) GetState() PromoState {
+	if x != nil {
+		return x.State
+	}
+	return PromoState_AnyState
+}
+
+func (x *PromoFilter) GetCreator() int64 {
+	if x != nil && x.Creator != nil {
+		return *x.Creator
+	}
+	return 0
+}
+
+func (x *PromoFilter) GetCurrency() common.Currency {
+	if x != nil && x.Currency != nil {
+		return *x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *PromoFilter) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *PromoFilter) GetOrder() PromoOrder {
+	if x != nil {
+		return x.Order
+	}
+	return PromoOrder_ByCreatedAt
+}
+
+func (x *PromoFilter) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type PromoInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoCode     *PromoCode             `protobuf:"bytes,1,opt,name=promoCode,proto3" json:"promoCode,omitempty"`
+	UsesLeft      int32                  `protobuf:"varint,2,opt,name=usesLeft,proto3" json:"usesLeft,omitempty"`  // -1 for infinity uses
+	ExpiresIn     *durationpb.Duration   `protobuf:"bytes,3,opt,name=expiresIn,proto3" json:"expiresIn,omitempty"` // Zero if promo expired
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoInfo) Reset() {
+	*x = PromoInfo{}
+	mi := &file_promos_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoInfo) ProtoMessage() {}
+
+func (x *PromoInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoInfo.ProtoReflect.Descriptor instead.
+func (*PromoInfo) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PromoInfo) GetPromoCode() *PromoCode {
+	if x != nil {
+		return x.PromoCode
+	}
+	return nil
+}
+
+func (x *PromoInfo) GetUsesLeft() int32 {
+	if x != nil {
+		return x.UsesLeft
+	}
+	return 0
+}
+
+func (x *PromoInfo) GetExpiresIn() *durationpb.Duration {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return nil
+}
+
+type AllPromos struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promos        []*PromoInfo           `protobuf:"bytes,1,rep,name=promos,proto3" json:"promos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty if it is last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllPromos) Reset() {
+	*x = AllPromos{}
+	mi := &file_promos_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllPromos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllPromos) ProtoMessage() {}
+
+func (x *AllPromos) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllPromos.ProtoReflect.Descriptor instead.
+func (*AllPromos) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AllPromos) GetPromos() []*PromoInfo {
+	if x != nil {
+		return x.Promos
+	}
+	return nil
+}
+
+func (x *AllPromos) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type AllPromosFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promos        *AllPromos             `protobuf:"bytes,1,opt,name=promos,proto3,oneof" json:"promos,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AllPromosFailure) Reset() {
+	*x = AllPromosFailure{}
+	mi := &file_promos_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AllPromosFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllPromosFailure) ProtoMessage() {}
+
+func (x *AllPromosFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllPromosFailure.ProtoReflect.Descriptor instead.
+func (*AllPromosFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AllPromosFailure) GetPromos() *AllPromos {
+	if x != nil {
+		return x.Promos
+	}
+	return nil
+}
+
+func (x *AllPromosFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type PromoStatsIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoId       int64                  `protobuf:"varint,1,opt,name=promoId,proto3" json:"promoId,omitempty"`
+	Bucket        StatsBucket            `protobuf:"varint,2,opt,name=bucket,proto3,enum=promocodes.StatsBucket" json:"bucket,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoStatsIn) Reset() {
+	*x = PromoStatsIn{}
+	mi := &file_promos_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoStatsIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoStatsIn) ProtoMessage() {}
+
+func (x *PromoStatsIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoStatsIn.ProtoReflect.Descriptor instead.
+func (*PromoStatsIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PromoStatsIn) GetPromoId() int64 {
+	if x != nil {
+		return x.PromoId
+	}
+	return 0
+}
+
+func (x *PromoStatsIn) GetBucket() StatsBucket {
+	if x != nil {
+		return x.Bucket
+	}
+	return StatsBucket_Day
+}
+
+type ActivationsBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Activations   int64                  `protobuf:"varint,2,opt,name=activations,proto3" json:"activations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivationsBucket) Reset() {
+	*x = ActivationsBucket{}
+	mi := &file_promos_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivationsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivationsBucket) ProtoMessage() {}
+
+func (x *ActivationsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivationsBucket.ProtoReflect.Descriptor instead.
+func (*ActivationsBucket) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ActivationsBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ActivationsBucket) GetActivations() int64 {
+	if x != nil {
+		return x.Activations
+	}
+	return 0
+}
+
+type PromoStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoId       int64                  `protobuf:"varint,1,opt,name=promoId,proto3" json:"promoId,omitempty"`
+	Activations   int64                  `protobuf:"varint,2,opt,name=activations,proto3" json:"activations,omitempty"`
+	UniqueUsers   int64                  `protobuf:"varint,3,opt,name=uniqueUsers,proto3" json:"uniqueUsers,omitempty"`
+	Buckets       []*ActivationsBucket   `protobuf:"bytes,6,rep,name=buckets,proto3" json:"buckets,omitempty"` // Oldest first, only buckets with activations
+	PaidOut       []*Reward              `protobuf:"bytes,7,rep,name=paidOut,proto3" json:"paidOut,omitempty"` // Total value given to users for every currency of rewards, ordered by currency
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoStats) Reset() {
+	*x = PromoStats{}
+	mi := &file_promos_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoStats) ProtoMessage() {}
+
+func (x *PromoStats) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoStats.ProtoReflect.Descriptor instead.
+func (*PromoStats) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PromoStats) GetPromoId() int64 {
+	if x != nil {
+		return x.PromoId
+	}
+	return 0
+}
+
+func (x *PromoStats) GetActivations() int64 {
+	if x != nil {
+		return x.Activations
+	}
+	return 0
+}
+
+func (x *PromoStats) GetUniqueUsers() int64 {
+	if x != nil {
+		return x.UniqueUsers
+	}
+	return 0
+}
+
+func (x *PromoStats) GetBuckets() []*ActivationsBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *PromoStats) GetPaidOut() []*Reward {
+	if x != nil {
+		return x.PaidOut
+	}
+	return nil
+}
+
+type PromoStatsFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stats         *PromoStats            `protobuf:"bytes,1,opt,name=stats,proto3,oneof" json:"stats,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoStatsFailure) Reset() {
+	*x = PromoStatsFailure{}
+	mi := &file_promos_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoStatsFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoStatsFailure) ProtoMessage() {}
+
+func (x *PromoStatsFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoStatsFailure.ProtoReflect.Descriptor instead.
+func (*PromoStatsFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PromoStatsFailure) GetStats() *PromoStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *PromoStatsFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type PromoActivationsIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromoId       int64                  `protobuf:"varint,1,opt,name=promoId,proto3" json:"promoId,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`        // Size of page, 0 is default size
+	PageToken     string                 `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // Empty for first page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoActivationsIn) Reset() {
+	*x = PromoActivationsIn{}
+	mi := &file_promos_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoActivationsIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoActivationsIn) ProtoMessage() {}
+
+func (x *PromoActivationsIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoActivationsIn.ProtoReflect.Descriptor instead.
+func (*PromoActivationsIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PromoActivationsIn) GetPromoId() int64 {
+	if x != nil {
+		return x.PromoId
+	}
+	return 0
+}
+
+func (x *PromoActivationsIn) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *PromoActivationsIn) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type PromoActivation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	ActivatedAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=activatedAt,proto3" json:"activatedAt,omitempty"`
+	Reward        *Reward                `protobuf:"bytes,3,opt,name=reward,proto3" json:"reward,omitempty"` // Reward granted to user
+	PromoId       int64                  `protobuf:"varint,4,opt,name=promoId,proto3" json:"promoId,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoActivation) Reset() {
+	*x = PromoActivation{}
+	mi := &file_promos_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoActivation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoActivation) ProtoMessage() {}
+
+func (x *PromoActivation) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoActivation.ProtoReflect.Descriptor instead.
+func (*PromoActivation) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PromoActivation) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PromoActivation) GetActivatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivatedAt
+	}
+	return nil
+}
+
+func (x *PromoActivation) GetReward() *Reward {
+	if x != nil {
+		return x.Reward
+	}
+	return nil
+}
+
+func (x *PromoActivation) GetPromoId() int64 {
+	if x != nil {
+		return x.PromoId
+	}
+	return 0
+}
+
+type PromoActivations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activations   []*PromoActivation     `protobuf:"bytes,1,rep,name=activations,proto3" json:"activations,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty if it is last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoActivations) Reset() {
+	*x = PromoActivations{}
+	mi := &file_promos_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoActivations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoActivations) ProtoMessage() {}
+
+func (x *PromoActivations) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoActivations.ProtoReflect.Descriptor instead.
+func (*PromoActivations) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PromoActivations) GetActivations() []*PromoActivation {
+	if x != nil {
+		return x.Activations
+	}
+	return nil
+}
+
+func (x *PromoActivations) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PromoActivationsFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Activations   *PromoActivations      `protobuf:"bytes,1,opt,name=activations,proto3,oneof" json:"activations,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoActivationsFailure) Reset() {
+	*x = PromoActivationsFailure{}
+	mi := &file_promos_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoActivationsFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoActivationsFailure) ProtoMessage() {}
+
+func (x *PromoActivationsFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoActivationsFailure.ProtoReflect.Descriptor instead.
+func (*PromoActivationsFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PromoActivationsFailure) GetActivations() *PromoActivations {
+	if x != nil {
+		return x.Activations
+	}
+	return nil
+}
+
+func (x *PromoActivationsFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type Campaign struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Currency      common.Currency        `protobuf:"varint,3,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"` // Reward for one code
+	Uses          int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`     // Redemptions left for all codes, -1 for infinity uses
+	Creator       int64                  `protobuf:"varint,6,opt,name=creator,proto3" json:"creator,omitempty"`
+	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expAt,proto3" json:"expAt,omitempty"` // Expiration of all codes
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Codes         int32                  `protobuf:"varint,9,opt,name=codes,proto3" json:"codes,omitempty"`        // Count of generated codes
+	Redeemed      int32                  `protobuf:"varint,10,opt,name=redeemed,proto3" json:"redeemed,omitempty"` // Count of redeemed codes
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Campaign) Reset() {
+	*x = Campaign{}
+	mi := &file_promos_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Campaign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Campaign) ProtoMessage() {}
+
+func (x *Campaign) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Campaign.ProtoReflect.Descriptor instead.
+func (*Campaign) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Campaign) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Campaign) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Campaign) GetCurrency() common.Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *Campaign) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Campaign) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+func (x *Campaign) GetCreator() int64 {
+	if x != nil {
+		return x.Creator
+	}
+	return 0
+}
+
+func (x *Campaign) GetExpAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Campaign) GetCodes() int32 {
+	if x != nil {
+		return x.Codes
+	}
+	return 0
+}
+
+func (x *Campaign) GetRedeemed() int32 {
+	if x != nil {
+		return x.Redeemed
+	}
+	return 0
+}
+
+type CreateCampaignIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      common.Currency        `protobuf:"varint,3,opt,name=currency,proto3,enum=common.Currency" json:"currency,omitempty"`
+	Creator       int64                  `protobuf:"varint,4,opt,name=creator,proto3" json:"creator,omitempty"`
+	Uses          int32                  `protobuf:"varint,5,opt,name=uses,proto3" json:"uses,omitempty"`
+	ExpAt         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expAt,proto3" json:"expAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCampaignIn) Reset() {
+	*x = CreateCampaignIn{}
+	mi := &file_promos_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCampaignIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCampaignIn) ProtoMessage() {}
+
+func (x *CreateCampaignIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCampaignIn.ProtoReflect.Descriptor instead.
+func (*CreateCampaignIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateCampaignIn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateCampaignIn) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateCampaignIn) GetCurrency() common.Currency {
+	if x != nil {
+		return x.Currency
+	}
+	return common.Currency(0)
+}
+
+func (x *CreateCampaignIn) GetCreator() int64 {
+	if x != nil {
+		return x.Creator
+	}
+	return 0
+}
+
+func (x *CreateCampaignIn) GetUses() int32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+func (x *CreateCampaignIn) GetExpAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpAt
+	}
+	return nil
+}
+
+type CampaignFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Campaign      *Campaign              `protobuf:"bytes,1,opt,name=campaign,proto3,oneof" json:"campaign,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignFailure) Reset() {
+	*x = CampaignFailure{}
+	mi := &file_promos_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignFailure) ProtoMessage() {}
+
+func (x *CampaignFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignFailure.ProtoReflect.Descriptor instead.
+func (*CampaignFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CampaignFailure) GetCampaign() *Campaign {
+	if x != nil {
+		return x.Campaign
+	}
+	return nil
+}
+
+func (x *CampaignFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type GenerateCodesIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int64                  `protobuf:"varint,1,opt,name=campaignId,proto3" json:"campaignId,omitempty"`
+	Creator       int64                  `protobuf:"varint,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	Count         int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`      // From 1 to 10000
+	Alphabet      string                 `protobuf:"bytes,4,opt,name=alphabet,proto3" json:"alphabet,omitempty"` // Optional, symbols of code
+	Length        int32                  `protobuf:"varint,5,opt,name=length,proto3" json:"length,omitempty"`    // Optional, length of code
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateCodesIn) Reset() {
+	*x = GenerateCodesIn{}
+	mi := &file_promos_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateCodesIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateCodesIn) ProtoMessage() {}
+
+func (x *GenerateCodesIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateCodesIn.ProtoReflect.Descriptor instead.
+func (*GenerateCodesIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GenerateCodesIn) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *GenerateCodesIn) GetCreator() int64 {
+	if x != nil {
+		return x.Creator
+	}
+	return 0
+}
+
+func (x *GenerateCodesIn) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GenerateCodesIn) GetAlphabet() string {
+	if x != nil {
+		return x.Alphabet
+	}
+	return ""
+}
+
+func (x *GenerateCodesIn) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type GeneratedCodes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []string               `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratedCodes) Reset() {
+	*x = GeneratedCodes{}
+	mi := &file_promos_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratedCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratedCodes) ProtoMessage() {}
+
+func (x *GeneratedCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratedCodes.ProtoReflect.Descriptor instead.
+func (*GeneratedCodes) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GeneratedCodes) GetCodes() []string {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+type GeneratedCodesFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         *GeneratedCodes        `protobuf:"bytes,1,opt,name=codes,proto3,oneof" json:"codes,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GeneratedCodesFailure) Reset() {
+	*x = GeneratedCodesFailure{}
+	mi := &file_promos_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GeneratedCodesFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GeneratedCodesFailure) ProtoMessage() {}
+
+func (x *GeneratedCodesFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GeneratedCodesFailure.ProtoReflect.Descriptor instead.
+func (*GeneratedCodesFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GeneratedCodesFailure) GetCodes() *GeneratedCodes {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *GeneratedCodesFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type ExportCodesIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int64                  `protobuf:"varint,1,opt,name=campaignId,proto3" json:"campaignId,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`        // Size of page, 0 is default size
+	PageToken     string                 `protobuf:"bytes,3,opt,name=pageToken,proto3" json:"pageToken,omitempty"` // Empty for first page
+	Creator       int64                  `protobuf:"varint,4,opt,name=creator,proto3" json:"creator,omitempty"`    // Caller, must be owner
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportCodesIn) Reset() {
+	*x = ExportCodesIn{}
+	mi := &file_promos_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportCodesIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportCodesIn) ProtoMessage() {}
+
+func (x *ExportCodesIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportCodesIn.ProtoReflect.Descriptor instead.
+func (*ExportCodesIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ExportCodesIn) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *ExportCodesIn) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ExportCodesIn) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ExportCodesIn) GetCreator() int64 {
+	if x != nil {
+		return x.Creator
+	}
+	return 0
+}
+
+type CampaignCode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Used          bool                   `protobuf:"varint,2,opt,name=used,proto3" json:"used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignCode) Reset() {
+	*x = CampaignCode{}
+	mi := &file_promos_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignCode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignCode) ProtoMessage() {}
+
+func (x *CampaignCode) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignCode.ProtoReflect.Descriptor instead.
+func (*CampaignCode) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *CampaignCode) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *CampaignCode) GetUsed() bool {
+	if x != nil {
+		return x.Used
+	}
+	return false
+}
+
+type CampaignCodes struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         []*CampaignCode        `protobuf:"bytes,1,rep,name=codes,proto3" json:"codes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"` // Empty if it is last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignCodes) Reset() {
+	*x = CampaignCodes{}
+	mi := &file_promos_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignCodes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignCodes) ProtoMessage() {}
+
+func (x *CampaignCodes) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignCodes.ProtoReflect.Descriptor instead.
+func (*CampaignCodes) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CampaignCodes) GetCodes() []*CampaignCode {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *CampaignCodes) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CampaignCodesFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Codes         *CampaignCodes         `protobuf:"bytes,1,opt,name=codes,proto3,oneof" json:"codes,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CampaignCodesFailure) Reset() {
+	*x = CampaignCodesFailure{}
+	mi := &file_promos_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CampaignCodesFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CampaignCodesFailure) ProtoMessage() {}
+
+func (x *CampaignCodesFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CampaignCodesFailure.ProtoReflect.Descriptor instead.
+func (*CampaignCodesFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CampaignCodesFailure) GetCodes() *CampaignCodes {
+	if x != nil {
+		return x.Codes
+	}
+	return nil
+}
+
+func (x *CampaignCodesFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type Referral struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=joinedAt,proto3" json:"joinedAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Referral) Reset() {
+	*x = Referral{}
+	mi := &file_promos_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Referral) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Referral) ProtoMessage() {}
+
+func (x *Referral) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Referral.ProtoReflect.Descriptor instead.
+func (*Referral) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *Referral) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Referral) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+type Referrals struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referrals     []*Referral            `protobuf:"bytes,1,rep,name=referrals,proto3" json:"referrals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Referrals) Reset() {
+	*x = Referrals{}
+	mi := &file_promos_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Referrals) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Referrals) ProtoMessage() {}
+
+func (x *Referrals) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Referrals.ProtoReflect.Descriptor instead.
+func (*Referrals) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *Referrals) GetReferrals() []*Referral {
+	if x != nil {
+		return x.Referrals
+	}
+	return nil
+}
+
+type ReferralsFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Referrals     *Referrals             `protobuf:"bytes,1,opt,name=referrals,proto3,oneof" json:"referrals,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReferralsFailure) Reset() {
+	*x = ReferralsFailure{}
+	mi := &file_promos_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReferralsFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferralsFailure) ProtoMessage() {}
+
+func (x *ReferralsFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferralsFailure.ProtoReflect.Descriptor instead.
+func (*ReferralsFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReferralsFailure) GetReferrals() *Referrals {
+	if x != nil {
+		return x.Referrals
+	}
+	return nil
+}
+
+func (x *ReferralsFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+// Columns of promo: name, amount, currency, uses, expAt, startsAt, userLimit, cooldown, rewards, audience.
+// Currency is name or number, timestamps in RFC 3339, cooldown like 24h, optional columns can be empty.
+// Rewards and audience are JSON of RewardTable and Audience, in CSV too.
+type ImportIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"` // Creator of imported promos
+	Format        FileFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=promocodes.FileFormat" json:"format,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportIn) Reset() {
+	*x = ImportIn{}
+	mi := &file_promos_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportIn) ProtoMessage() {}
+
+func (x *ImportIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportIn.ProtoReflect.Descriptor instead.
+func (*ImportIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ImportIn) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImportIn) GetFormat() FileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FileFormat_CSV
+}
+
+func (x *ImportIn) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int32                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"` // Number of row in file, from 1 without header
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_promos_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ImportRowError) GetRow() int32 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type ImportResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promos        []*PromoCode           `protobuf:"bytes,1,rep,name=promos,proto3" json:"promos,omitempty"` // Inserted promos
+	Errors        []*ImportRowError      `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResult) Reset() {
+	*x = ImportResult{}
+	mi := &file_promos_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResult) ProtoMessage() {}
+
+func (x *ImportResult) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResult.ProtoReflect.Descriptor instead.
+func (*ImportResult) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ImportResult) GetPromos() []*PromoCode {
+	if x != nil {
+		return x.Promos
+	}
+	return nil
+}
+
+func (x *ImportResult) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ImportResultFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *ImportResult          `protobuf:"bytes,1,opt,name=result,proto3,oneof" json:"result,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportResultFailure) Reset() {
+	*x = ImportResultFailure{}
+	mi := &file_promos_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportResultFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportResultFailure) ProtoMessage() {}
+
+func (x *ImportResultFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportResultFailure.ProtoReflect.Descriptor instead.
+func (*ImportResultFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ImportResultFailure) GetResult() *ImportResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ImportResultFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+type ExportIn struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=userId,proto3" json:"userId,omitempty"`
+	Format        FileFormat             `protobuf:"varint,2,opt,name=format,proto3,enum=promocodes.FileFormat" json:"format,omitempty"`
+	Creator       int64                  `protobuf:"varint,3,opt,name=creator,proto3" json:"creator,omitempty"` // Optional, only promos of creator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportIn) Reset() {
+	*x = ExportIn{}
+	mi := &file_promos_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportIn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportIn) ProtoMessage() {}
+
+func (x *ExportIn) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportIn.ProtoReflect.Descriptor instead.
+func (*ExportIn) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ExportIn) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ExportIn) GetFormat() FileFormat {
+	if x != nil {
+		return x.Format
+	}
+	return FileFormat_CSV
+}
+
+func (x *ExportIn) GetCreator() int64 {
+	if x != nil {
+		return x.Creator
+	}
+	return 0
+}
+
+// Promos have columns of import and id, creator, createdAt, isActive, deletedAt, deletedBy.
+// Deleted promos and their activations are exported, deletedAt is empty for not deleted promo.
+// Activations have columns promoId, userId, activatedAt, currency, amount.
+type ExportFiles struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promos        []byte                 `protobuf:"bytes,1,opt,name=promos,proto3" json:"promos,omitempty"`
+	Activations   []byte                 `protobuf:"bytes,2,opt,name=activations,proto3" json:"activations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFiles) Reset() {
+	*x = ExportFiles{}
+	mi := &file_promos_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFiles) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFiles) ProtoMessage() {}
+
+func (x *ExportFiles) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFiles.ProtoReflect.Descriptor instead.
+func (*ExportFiles) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ExportFiles) GetPromos() []byte {
+	if x != nil {
+		return x.Promos
+	}
+	return nil
+}
+
+func (x *ExportFiles) GetActivations() []byte {
+	if x != nil {
+		return x.Activations
+	}
+	return nil
+}
+
+type ExportFilesFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         *ExportFiles           `protobuf:"bytes,1,opt,name=files,proto3,oneof" json:"files,omitempty"`
+	Failure       *common.Failure        `protobuf:"bytes,2,opt,name=failure,proto3,oneof" json:"failure,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportFilesFailure) Reset() {
+	*x = ExportFilesFailure{}
+	mi := &file_promos_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportFilesFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportFilesFailure) ProtoMessage() {}
+
+func (x *ExportFilesFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_promos_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportFilesFailure.ProtoReflect.Descriptor instead.
+func (*ExportFilesFailure) Descriptor() ([]byte, []int) {
+	return file_promos_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ExportFilesFailure) GetFiles() *ExportFiles {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ExportFilesFailure) GetFailure() *common.Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+var File_promos_service_proto protoreflect.FileDescriptor
+
+const file_promos_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14promos/service.proto\x12\n" +
+	"promocodes\x1a\x12common/types.proto\x1a\x13users/service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\xb5\x01\n" +
+	"\tAddTimeIn\x12\x18\n" +
+	"\apromoId\x18\x01 \x01(\x03R\apromoId\x120\n" +
+	"\x05expAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x126\n" +
+	"\bstartsAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12$\n" +
+	"\rclearStartsAt\x18\x04 \x01(\bR\rclearStartsAt\"9\n" +
+	"\tAddUsesIn\x12\x18\n" +
+	"\apromoId\x18\x01 \x01(\x03R\apromoId\x12\x12\n" +
+	"\x04uses\x18\x02 \x01(\x05R\x04uses\"\x1f\n" +
+	"\tPromoName\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x19\n" +
+	"\aPromoId\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xd9\x05\n" +
+	"\tPromoCode\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
+	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x12\n" +
+	"\x04uses\x18\x05 \x01(\x05R\x04uses\x12\x18\n" +
+	"\acreator\x18\x06 \x01(\x03R\acreator\x120\n" +
+	"\x05expAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x128\n" +
+	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
+	"\bstartsAt\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x12\x1a\n" +
+	"\bisActive\x18\n" +
+	" \x01(\bR\bisActive\x12\x1e\n" +
+	"\n" +
+	"campaignId\x18\v \x01(\x03R\n" +
+	"campaignId\x120\n" +
+	"\baudience\x18\f \x01(\v2\x14.promocodes.AudienceR\baudience\x12\x1a\n" +
+	"\breferrer\x18\r \x01(\x03R\breferrer\x12&\n" +
+	"\x0ereferrerAmount\x18\x0e \x01(\x03R\x0ereferrerAmount\x12\x1c\n" +
+	"\tuserLimit\x18\x0f \x01(\x05R\tuserLimit\x125\n" +
+	"\bcooldown\x18\x10 \x01(\v2\x19.google.protobuf.DurationR\bcooldown\x121\n" +
+	"\arewards\x18\x11 \x01(\v2\x17.promocodes.RewardTableR\arewards\x128\n" +
+	"\tdeletedAt\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1c\n" +
+	"\tdeletedBy\x18\x13 \x01(\x03R\tdeletedBy\"\xb9\x03\n" +
+	"\vCreatePromo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12,\n" +
+	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x18\n" +
+	"\acreator\x18\x04 \x01(\x03R\acreator\x12\x12\n" +
+	"\x04uses\x18\x05 \x01(\x05R\x04uses\x120\n" +
+	"\x05expAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x126\n" +
+	"\bstartsAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x120\n" +
+	"\baudience\x18\b \x01(\v2\x14.promocodes.AudienceR\baudience\x12\x1c\n" +
+	"\tuserLimit\x18\t \x01(\x05R\tuserLimit\x125\n" +
+	"\bcooldown\x18\n" +
+	" \x01(\v2\x19.google.protobuf.DurationR\bcooldown\x121\n" +
+	"\arewards\x18\v \x01(\v2\x17.promocodes.RewardTableR\arewards\"o\n" +
+	"\vRewardTable\x12,\n" +
+	"\x05tiers\x18\x01 \x03(\v2\x16.promocodes.RewardTierR\x05tiers\x122\n" +
+	"\aoptions\x18\x02 \x03(\v2\x18.promocodes.RewardOptionR\aoptions\"f\n" +
+	"\n" +
+	"RewardTier\x12\x12\n" +
+	"\x04upTo\x18\x01 \x01(\x03R\x04upTo\x12,\n" +
+	"\bcurrency\x18\x02 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"l\n" +
+	"\fRewardOption\x12\x16\n" +
+	"\x06weight\x18\x01 \x01(\x05R\x06weight\x12,\n" +
+	"\bcurrency\x18\x02 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"N\n" +
+	"\x06Reward\x12,\n" +
+	"\bcurrency\x18\x01 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\"\x87\x01\n" +
+	"\rRewardFailure\x12/\n" +
+	"\x06reward\x18\x01 \x01(\v2\x12.promocodes.RewardH\x00R\x06reward\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\t\n" +
+	"\a_rewardB\n" +
+	"\n" +
+	"\b_failure\"\xc5\x01\n" +
+	"\bAudience\x12!\n" +
+	"\x05roles\x18\x01 \x03(\x0e2\v.users.RoleR\x05roles\x12>\n" +
+	"\fcreatedAfter\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12@\n" +
+	"\rcreatedBefore\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x14\n" +
+	"\x05users\x18\x04 \x03(\x03R\x05users\"\x92\x01\n" +
+	"\fPromoFailure\x128\n" +
+	"\tpromoCode\x18\x01 \x01(\v2\x15.promocodes.PromoCodeH\x00R\tpromoCode\x88\x01\x01\x12.\n" +
 	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\f\n" +
 	"\n" +
 	"_promoCodeB\n" +
@@ -545,13 +3244,220 @@ const file_promos_service_proto_rawDesc = "" +
 	"\b_failure\"?\n" +
 	"\vPromoUserId\x12\x16\n" +
 	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x18\n" +
-	"\apromoId\x18\x02 \x01(\x03R\apromoId2\x92\x03\n" +
+	"\apromoId\x18\x02 \x01(\x03R\apromoId\";\n" +
+	"\rPromoUserName\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xc8\x02\n" +
+	"\vPromoFilter\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tpageToken\x18\x02 \x01(\tR\tpageToken\x12,\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x16.promocodes.PromoStateR\x05state\x12\x1d\n" +
+	"\acreator\x18\x04 \x01(\x03H\x00R\acreator\x88\x01\x01\x121\n" +
+	"\bcurrency\x18\x05 \x01(\x0e2\x10.common.CurrencyH\x01R\bcurrency\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"namePrefix\x18\x06 \x01(\tR\n" +
+	"namePrefix\x12,\n" +
+	"\x05order\x18\a \x01(\x0e2\x16.promocodes.PromoOrderR\x05order\x12\x1e\n" +
+	"\n" +
+	"descending\x18\b \x01(\bR\n" +
+	"descendingB\n" +
+	"\n" +
+	"\b_creatorB\v\n" +
+	"\t_currency\"\x95\x01\n" +
+	"\tPromoInfo\x123\n" +
+	"\tpromoCode\x18\x01 \x01(\v2\x15.promocodes.PromoCodeR\tpromoCode\x12\x1a\n" +
+	"\busesLeft\x18\x02 \x01(\x05R\busesLeft\x127\n" +
+	"\texpiresIn\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\texpiresIn\"`\n" +
+	"\tAllPromos\x12-\n" +
+	"\x06promos\x18\x01 \x03(\v2\x15.promocodes.PromoInfoR\x06promos\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\x8d\x01\n" +
+	"\x10AllPromosFailure\x122\n" +
+	"\x06promos\x18\x01 \x01(\v2\x15.promocodes.AllPromosH\x00R\x06promos\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\t\n" +
+	"\a_promosB\n" +
+	"\n" +
+	"\b_failure\"Y\n" +
+	"\fPromoStatsIn\x12\x18\n" +
+	"\apromoId\x18\x01 \x01(\x03R\apromoId\x12/\n" +
+	"\x06bucket\x18\x02 \x01(\x0e2\x17.promocodes.StatsBucketR\x06bucket\"g\n" +
+	"\x11ActivationsBucket\x120\n" +
+	"\x05start\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05start\x12 \n" +
+	"\vactivations\x18\x02 \x01(\x03R\vactivations\"\xdd\x01\n" +
+	"\n" +
+	"PromoStats\x12\x18\n" +
+	"\apromoId\x18\x01 \x01(\x03R\apromoId\x12 \n" +
+	"\vactivations\x18\x02 \x01(\x03R\vactivations\x12 \n" +
+	"\vuniqueUsers\x18\x03 \x01(\x03R\vuniqueUsers\x127\n" +
+	"\abuckets\x18\x06 \x03(\v2\x1d.promocodes.ActivationsBucketR\abuckets\x12,\n" +
+	"\apaidOut\x18\a \x03(\v2\x12.promocodes.RewardR\apaidOutJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\"\x8c\x01\n" +
+	"\x11PromoStatsFailure\x121\n" +
+	"\x05stats\x18\x01 \x01(\v2\x16.promocodes.PromoStatsH\x00R\x05stats\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
+	"\x06_statsB\n" +
+	"\n" +
+	"\b_failure\"b\n" +
+	"\x12PromoActivationsIn\x12\x18\n" +
+	"\apromoId\x18\x01 \x01(\x03R\apromoId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tpageToken\x18\x03 \x01(\tR\tpageToken\"\xad\x01\n" +
+	"\x0fPromoActivation\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12<\n" +
+	"\vactivatedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vactivatedAt\x12*\n" +
+	"\x06reward\x18\x03 \x01(\v2\x12.promocodes.RewardR\x06reward\x12\x18\n" +
+	"\apromoId\x18\x04 \x01(\x03R\apromoId\"w\n" +
+	"\x10PromoActivations\x12=\n" +
+	"\vactivations\x18\x01 \x03(\v2\x1b.promocodes.PromoActivationR\vactivations\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\xaa\x01\n" +
+	"\x17PromoActivationsFailure\x12C\n" +
+	"\vactivations\x18\x01 \x01(\v2\x1c.promocodes.PromoActivationsH\x00R\vactivations\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\x0e\n" +
+	"\f_activationsB\n" +
+	"\n" +
+	"\b_failure\"\xc0\x02\n" +
+	"\bCampaign\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12,\n" +
+	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x12\n" +
+	"\x04uses\x18\x05 \x01(\x05R\x04uses\x12\x18\n" +
+	"\acreator\x18\x06 \x01(\x03R\acreator\x120\n" +
+	"\x05expAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\x128\n" +
+	"\tcreatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05codes\x18\t \x01(\x05R\x05codes\x12\x1a\n" +
+	"\bredeemed\x18\n" +
+	" \x01(\x05R\bredeemed\"\xcc\x01\n" +
+	"\x10CreateCampaignIn\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12,\n" +
+	"\bcurrency\x18\x03 \x01(\x0e2\x10.common.CurrencyR\bcurrency\x12\x18\n" +
+	"\acreator\x18\x04 \x01(\x03R\acreator\x12\x12\n" +
+	"\x04uses\x18\x05 \x01(\x05R\x04uses\x120\n" +
+	"\x05expAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x05expAt\"\x91\x01\n" +
+	"\x0fCampaignFailure\x125\n" +
+	"\bcampaign\x18\x01 \x01(\v2\x14.promocodes.CampaignH\x00R\bcampaign\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\v\n" +
+	"\t_campaignB\n" +
+	"\n" +
+	"\b_failure\"\x95\x01\n" +
+	"\x0fGenerateCodesIn\x12\x1e\n" +
+	"\n" +
+	"campaignId\x18\x01 \x01(\x03R\n" +
+	"campaignId\x12\x18\n" +
+	"\acreator\x18\x02 \x01(\x03R\acreator\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12\x1a\n" +
+	"\balphabet\x18\x04 \x01(\tR\balphabet\x12\x16\n" +
+	"\x06length\x18\x05 \x01(\x05R\x06length\"&\n" +
+	"\x0eGeneratedCodes\x12\x14\n" +
+	"\x05codes\x18\x01 \x03(\tR\x05codes\"\x94\x01\n" +
+	"\x15GeneratedCodesFailure\x125\n" +
+	"\x05codes\x18\x01 \x01(\v2\x1a.promocodes.GeneratedCodesH\x00R\x05codes\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
+	"\x06_codesB\n" +
+	"\n" +
+	"\b_failure\"}\n" +
+	"\rExportCodesIn\x12\x1e\n" +
+	"\n" +
+	"campaignId\x18\x01 \x01(\x03R\n" +
+	"campaignId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1c\n" +
+	"\tpageToken\x18\x03 \x01(\tR\tpageToken\x12\x18\n" +
+	"\acreator\x18\x04 \x01(\x03R\acreator\"6\n" +
+	"\fCampaignCode\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04used\x18\x02 \x01(\bR\x04used\"e\n" +
+	"\rCampaignCodes\x12.\n" +
+	"\x05codes\x18\x01 \x03(\v2\x18.promocodes.CampaignCodeR\x05codes\x12$\n" +
+	"\rnextPageToken\x18\x02 \x01(\tR\rnextPageToken\"\x92\x01\n" +
+	"\x14CampaignCodesFailure\x124\n" +
+	"\x05codes\x18\x01 \x01(\v2\x19.promocodes.CampaignCodesH\x00R\x05codes\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
+	"\x06_codesB\n" +
+	"\n" +
+	"\b_failure\"Z\n" +
+	"\bReferral\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x126\n" +
+	"\bjoinedAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"?\n" +
+	"\tReferrals\x122\n" +
+	"\treferrals\x18\x01 \x03(\v2\x14.promocodes.ReferralR\treferrals\"\x96\x01\n" +
+	"\x10ReferralsFailure\x128\n" +
+	"\treferrals\x18\x01 \x01(\v2\x15.promocodes.ReferralsH\x00R\treferrals\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\f\n" +
+	"\n" +
+	"_referralsB\n" +
+	"\n" +
+	"\b_failure\"f\n" +
+	"\bImportIn\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12.\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x16.promocodes.FileFormatR\x06format\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"8\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x05R\x03row\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"q\n" +
+	"\fImportResult\x12-\n" +
+	"\x06promos\x18\x01 \x03(\v2\x15.promocodes.PromoCodeR\x06promos\x122\n" +
+	"\x06errors\x18\x02 \x03(\v2\x1a.promocodes.ImportRowErrorR\x06errors\"\x93\x01\n" +
+	"\x13ImportResultFailure\x125\n" +
+	"\x06result\x18\x01 \x01(\v2\x18.promocodes.ImportResultH\x00R\x06result\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\t\n" +
+	"\a_resultB\n" +
+	"\n" +
+	"\b_failure\"l\n" +
+	"\bExportIn\x12\x16\n" +
+	"\x06userId\x18\x01 \x01(\x03R\x06userId\x12.\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x16.promocodes.FileFormatR\x06format\x12\x18\n" +
+	"\acreator\x18\x03 \x01(\x03R\acreator\"G\n" +
+	"\vExportFiles\x12\x16\n" +
+	"\x06promos\x18\x01 \x01(\fR\x06promos\x12 \n" +
+	"\vactivations\x18\x02 \x01(\fR\vactivations\"\x8e\x01\n" +
+	"\x12ExportFilesFailure\x122\n" +
+	"\x05files\x18\x01 \x01(\v2\x17.promocodes.ExportFilesH\x00R\x05files\x88\x01\x01\x12.\n" +
+	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
+	"\x06_filesB\n" +
+	"\n" +
+	"\b_failure*]\n" +
+	"\n" +
+	"PromoState\x12\f\n" +
+	"\bAnyState\x10\x00\x12\n" +
+	"\n" +
+	"\x06Active\x10\x01\x12\v\n" +
+	"\aExpired\x10\x02\x12\r\n" +
+	"\tExhausted\x10\x03\x12\r\n" +
+	"\tScheduled\x10\x04\x12\n" +
+	"\n" +
+	"\x06Paused\x10\x05**\n" +
+	"\n" +
+	"PromoOrder\x12\x0f\n" +
+	"\vByCreatedAt\x10\x00\x12\v\n" +
+	"\aByExpAt\x10\x01* \n" +
+	"\vStatsBucket\x12\a\n" +
+	"\x03Day\x10\x00\x12\b\n" +
+	"\x04Hour\x10\x01*\x1f\n" +
+	"\n" +
+	"FileFormat\x12\a\n" +
+	"\x03CSV\x10\x00\x12\b\n" +
+	"\x04JSON\x10\x012\xed\n" +
+	"\n" +
 	"\x06Promos\x12;\n" +
-	"\x06Create\x12\x17.promocodes.CreatePromo\x1a\x18.promocodes.PromoFailure\x12/\n" +
-	"\x06Delete\x12\x13.promocodes.PromoId\x1a\x10.common.Response\x128\n" +
+	"\x06Create\x12\x17.promocodes.CreatePromo\x1a\x18.promocodes.PromoFailure\x123\n" +
+	"\x06Delete\x12\x17.promocodes.PromoUserId\x1a\x10.common.Response\x12;\n" +
+	"\fDeleteByName\x12\x19.promocodes.PromoUserName\x1a\x10.common.Response\x126\n" +
+	"\rDeleteHistory\x12\x13.promocodes.PromoId\x1a\x10.common.Response\x128\n" +
 	"\aGetById\x12\x13.promocodes.PromoId\x1a\x18.promocodes.PromoFailure\x12<\n" +
-	"\tGetByName\x12\x15.promocodes.PromoName\x1a\x18.promocodes.PromoFailure\x12:\n" +
-	"\x03Use\x12\x17.promocodes.PromoUserId\x1a\x1a.users.TransactionResponse\x122\n" +
+	"\tGetByName\x12\x15.promocodes.PromoName\x1a\x18.promocodes.PromoFailure\x12=\n" +
+	"\x04List\x12\x17.promocodes.PromoFilter\x1a\x1c.promocodes.AllPromosFailure\x12C\n" +
+	"\bGetStats\x12\x18.promocodes.PromoStatsIn\x1a\x1d.promocodes.PromoStatsFailure\x12U\n" +
+	"\x0eGetActivations\x12\x1e.promocodes.PromoActivationsIn\x1a#.promocodes.PromoActivationsFailure\x129\n" +
+	"\x03Use\x12\x17.promocodes.PromoUserId\x1a\x19.promocodes.RewardFailure\x12A\n" +
+	"\tUseByName\x12\x19.promocodes.PromoUserName\x1a\x19.promocodes.RewardFailure\x122\n" +
+	"\x05Pause\x12\x17.promocodes.PromoUserId\x1a\x10.common.Response\x123\n" +
+	"\x06Resume\x12\x17.promocodes.PromoUserId\x1a\x10.common.Response\x12K\n" +
+	"\x0eCreateCampaign\x12\x1c.promocodes.CreateCampaignIn\x1a\x1b.promocodes.CampaignFailure\x12O\n" +
+	"\rGenerateCodes\x12\x1b.promocodes.GenerateCodesIn\x1a!.promocodes.GeneratedCodesFailure\x12J\n" +
+	"\vExportCodes\x12\x19.promocodes.ExportCodesIn\x1a .promocodes.CampaignCodesFailure\x126\n" +
+	"\x0fGetReferralCode\x12\t.users.Id\x1a\x18.promocodes.PromoFailure\x127\n" +
+	"\fGetReferrals\x12\t.users.Id\x1a\x1c.promocodes.ReferralsFailure\x12?\n" +
+	"\x06Import\x12\x14.promocodes.ImportIn\x1a\x1f.promocodes.ImportResultFailure\x12>\n" +
+	"\x06Export\x12\x14.promocodes.ExportIn\x1a\x1e.promocodes.ExportFilesFailure\x122\n" +
 	"\aAddTime\x12\x15.promocodes.AddTimeIn\x1a\x10.common.Response\x122\n" +
 	"\aAddUses\x12\x15.promocodes.AddUsesIn\x1a\x10.common.ResponseB\n" +
 	"Z\b./promosb\x06proto3"
@@ -568,50 +3474,189 @@ func file_promos_service_proto_rawDescGZIP() []byte {
 	return file_promos_service_proto_rawDescData
 }
 
-var file_promos_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_promos_service_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_promos_service_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_promos_service_proto_goTypes = []any{
-	(*AddTimeIn)(nil),                 // 0: promocodes.AddTimeIn
-	(*AddUsesIn)(nil),                 // 1: promocodes.AddUsesIn
-	(*PromoName)(nil),                 // 2: promocodes.PromoName
-	(*PromoId)(nil),                   // 3: promocodes.PromoId
-	(*PromoCode)(nil),                 // 4: promocodes.PromoCode
-	(*CreatePromo)(nil),               // 5: promocodes.CreatePromo
-	(*PromoFailure)(nil),              // 6: promocodes.PromoFailure
-	(*PromoUserId)(nil),               // 7: promocodes.PromoUserId
-	(*timestamppb.Timestamp)(nil),     // 8: google.protobuf.Timestamp
-	(common.Currency)(0),              // 9: common.Currency
-	(*common.Failure)(nil),            // 10: common.Failure
-	(*common.Response)(nil),           // 11: common.Response
-	(*users.TransactionResponse)(nil), // 12: users.TransactionResponse
+	(PromoState)(0),                 // 0: promocodes.PromoState
+	(PromoOrder)(0),                 // 1: promocodes.PromoOrder
+	(StatsBucket)(0),                // 2: promocodes.StatsBucket
+	(FileFormat)(0),                 // 3: promocodes.FileFormat
+	(*AddTimeIn)(nil),               // 4: promocodes.AddTimeIn
+	(*AddUsesIn)(nil),               // 5: promocodes.AddUsesIn
+	(*PromoName)(nil),               // 6: promocodes.PromoName
+	(*PromoId)(nil),                 // 7: promocodes.PromoId
+	(*PromoCode)(nil),               // 8: promocodes.PromoCode
+	(*CreatePromo)(nil),             // 9: promocodes.CreatePromo
+	(*RewardTable)(nil),             // 10: promocodes.RewardTable
+	(*RewardTier)(nil),              // 11: promocodes.RewardTier
+	(*RewardOption)(nil),            // 12: promocodes.RewardOption
+	(*Reward)(nil),                  // 13: promocodes.Reward
+	(*RewardFailure)(nil),           // 14: promocodes.RewardFailure
+	(*Audience)(nil),                // 15: promocodes.Audience
+	(*PromoFailure)(nil),            // 16: promocodes.PromoFailure
+	(*PromoUserId)(nil),             // 17: promocodes.PromoUserId
+	(*PromoUserName)(nil),           // 18: promocodes.PromoUserName
+	(*PromoFilter)(nil),             // 19: promocodes.PromoFilter
+	(*PromoInfo)(nil),               // 20: promocodes.PromoInfo
+	(*AllPromos)(nil),               // 21: promocodes.AllPromos
+	(*AllPromosFailure)(nil),        // 22: promocodes.AllPromosFailure
+	(*PromoStatsIn)(nil),            // 23: promocodes.PromoStatsIn
+	(*ActivationsBucket)(nil),       // 24: promocodes.ActivationsBucket
+	(*PromoStats)(nil),              // 25: promocodes.PromoStats
+	(*PromoStatsFailure)(nil),       // 26: promocodes.PromoStatsFailure
+	(*PromoActivationsIn)(nil),      // 27: promocodes.PromoActivationsIn
+	(*PromoActivation)(nil),         // 28: promocodes.PromoActivation
+	(*PromoActivations)(nil),        // 29: promocodes.PromoActivations
+	(*PromoActivationsFailure)(nil), // 30: promocodes.PromoActivationsFailure
+	(*Campaign)(nil),                // 31: promocodes.Campaign
+	(*CreateCampaignIn)(nil),        // 32: promocodes.CreateCampaignIn
+	(*CampaignFailure)(nil),         // 33: promocodes.CampaignFailure
+	(*GenerateCodesIn)(nil),         // 34: promocodes.GenerateCodesIn
+	(*GeneratedCodes)(nil),          // 35: promocodes.GeneratedCodes
+	(*GeneratedCodesFailure)(nil),   // 36: promocodes.GeneratedCodesFailure
+	(*ExportCodesIn)(nil),           // 37: promocodes.ExportCodesIn
+	(*CampaignCode)(nil),            // 38: promocodes.CampaignCode
+	(*CampaignCodes)(nil),           // 39: promocodes.CampaignCodes
+	(*CampaignCodesFailure)(nil),    // 40: promocodes.CampaignCodesFailure
+	(*Referral)(nil),                // 41: promocodes.Referral
+	(*Referrals)(nil),               // 42: promocodes.Referrals
+	(*ReferralsFailure)(nil),        // 43: promocodes.ReferralsFailure
+	(*ImportIn)(nil),                // 44: promocodes.ImportIn
+	(*ImportRowError)(nil),          // 45: promocodes.ImportRowError
+	(*ImportResult)(nil),            // 46: promocodes.ImportResult
+	(*ImportResultFailure)(nil),     // 47: promocodes.ImportResultFailure
+	(*ExportIn)(nil),                // 48: promocodes.ExportIn
+	(*ExportFiles)(nil),             // 49: promocodes.ExportFiles
+	(*ExportFilesFailure)(nil),      // 50: promocodes.ExportFilesFailure
+	(*timestamppb.Timestamp)(nil),   // 51: google.protobuf.Timestamp
+	(common.Currency)(0),            // 52: common.Currency
+	(*durationpb.Duration)(nil),     // 53: google.protobuf.Duration
+	(*common.Failure)(nil),          // 54: common.Failure
+	(users.Role)(0),                 // 55: users.Role
+	(*users.Id)(nil),                // 56: users.Id
+	(*common.Response)(nil),         // 57: common.Response
 }
 var file_promos_service_proto_depIdxs = []int32{
-	8,  // 0: promocodes.AddTimeIn.expAt:type_name -> google.protobuf.Timestamp
-	9,  // 1: promocodes.PromoCode.currency:type_name -> common.Currency
-	8,  // 2: promocodes.PromoCode.expAt:type_name -> google.protobuf.Timestamp
-	8,  // 3: promocodes.PromoCode.createdAt:type_name -> google.protobuf.Timestamp
-	9,  // 4: promocodes.CreatePromo.currency:type_name -> common.Currency
-	8,  // 5: promocodes.CreatePromo.expAt:type_name -> google.protobuf.Timestamp
-	4,  // 6: promocodes.PromoFailure.promoCode:type_name -> promocodes.PromoCode
-	10, // 7: promocodes.PromoFailure.failure:type_name -> common.Failure
-	5,  // 8: promocodes.Promos.Create:input_type -> promocodes.CreatePromo
-	3,  // 9: promocodes.Promos.Delete:input_type -> promocodes.PromoId
-	3,  // 10: promocodes.Promos.GetById:input_type -> promocodes.PromoId
-	2,  // 11: promocodes.Promos.GetByName:input_type -> promocodes.PromoName
-	7,  // 12: promocodes.Promos.Use:input_type -> promocodes.PromoUserId
-	0,  // 13: promocodes.Promos.AddTime:input_type -> promocodes.AddTimeIn
-	1,  // 14: promocodes.Promos.AddUses:input_type -> promocodes.AddUsesIn
-	6,  // 15: promocodes.Promos.Create:output_type -> promocodes.PromoFailure
-	11, // 16: promocodes.Promos.Delete:output_type -> common.Response
-	6,  // 17: promocodes.Promos.GetById:output_type -> promocodes.PromoFailure
-	6,  // 18: promocodes.Promos.GetByName:output_type -> promocodes.PromoFailure
-	12, // 19: promocodes.Promos.Use:output_type -> users.TransactionResponse
-	11, // 20: promocodes.Promos.AddTime:output_type -> common.Response
-	11, // 21: promocodes.Promos.AddUses:output_type -> common.Response
-	15, // [15:22] is the sub-list for method output_type
-	8,  // [8:15] is the sub-list for method input_type
-	8,  // [8:8] is the sub-list for extension type_name
-	8,  // [8:8] is the sub-list for extension extendee
-	0,  // [0:8] is the sub-list for field type_name
+	51, // 0: promocodes.AddTimeIn.expAt:type_name -> google.protobuf.Timestamp
+	51, // 1: promocodes.AddTimeIn.startsAt:type_name -> google.protobuf.Timestamp
+	52, // 2: promocodes.PromoCode.currency:type_name -> common.Currency
+	51, // 3: promocodes.PromoCode.expAt:type_name -> google.protobuf.Timestamp
+	51, // 4: promocodes.PromoCode.createdAt:type_name -> google.protobuf.Timestamp
+	51, // 5: promocodes.PromoCode.startsAt:type_name -> google.protobuf.Timestamp
+	15, // 6: promocodes.PromoCode.audience:type_name -> promocodes.Audience
+	53, // 7: promocodes.PromoCode.cooldown:type_name -> google.protobuf.Duration
+	10, // 8: promocodes.PromoCode.rewards:type_name -> promocodes.RewardTable
+	51, // 9: promocodes.PromoCode.deletedAt:type_name -> google.protobuf.Timestamp
+	52, // 10: promocodes.CreatePromo.currency:type_name -> common.Currency
+	51, // 11: promocodes.CreatePromo.expAt:type_name -> google.protobuf.Timestamp
+	51, // 12: promocodes.CreatePromo.startsAt:type_name -> google.protobuf.Timestamp
+	15, // 13: promocodes.CreatePromo.audience:type_name -> promocodes.Audience
+	53, // 14: promocodes.CreatePromo.cooldown:type_name -> google.protobuf.Duration
+	10, // 15: promocodes.CreatePromo.rewards:type_name -> promocodes.RewardTable
+	11, // 16: promocodes.RewardTable.tiers:type_name -> promocodes.RewardTier
+	12, // 17: promocodes.RewardTable.options:type_name -> promocodes.RewardOption
+	52, // 18: promocodes.RewardTier.currency:type_name -> common.Currency
+	52, // 19: promocodes.RewardOption.currency:type_name -> common.Currency
+	52, // 20: promocodes.Reward.currency:type_name -> common.Currency
+	13, // 21: promocodes.RewardFailure.reward:type_name -> promocodes.Reward
+	54, // 22: promocodes.RewardFailure.failure:type_name -> common.Failure
+	55, // 23: promocodes.Audience.roles:type_name -> users.Role
+	51, // 24: promocodes.Audience.createdAfter:type_name -> google.protobuf.Timestamp
+	51, // 25: promocodes.Audience.createdBefore:type_name -> google.protobuf.Timestamp
+	8,  // 26: promocodes.PromoFailure.promoCode:type_name -> promocodes.PromoCode
+	54, // 27: promocodes.PromoFailure.failure:type_name -> common.Failure
+	0,  // 28: promocodes.PromoFilter.state:type_name -> promocodes.PromoState
+	52, // 29: promocodes.PromoFilter.currency:type_name -> common.Currency
+	1,  // 30: promocodes.PromoFilter.order:type_name -> promocodes.PromoOrder
+	8,  // 31: promocodes.PromoInfo.promoCode:type_name -> promocodes.PromoCode
+	53, // 32: promocodes.PromoInfo.expiresIn:type_name -> google.protobuf.Duration
+	20, // 33: promocodes.AllPromos.promos:type_name -> promocodes.PromoInfo
+	21, // 34: promocodes.AllPromosFailure.promos:type_name -> promocodes.AllPromos
+	54, // 35: promocodes.AllPromosFailure.failure:type_name -> common.Failure
+	2,  // 36: promocodes.PromoStatsIn.bucket:type_name -> promocodes.StatsBucket
+	51, // 37: promocodes.ActivationsBucket.start:type_name -> google.protobuf.Timestamp
+	24, // 38: promocodes.PromoStats.buckets:type_name -> promocodes.ActivationsBucket
+	13, // 39: promocodes.PromoStats.paidOut:type_name -> promocodes.Reward
+	25, // 40: promocodes.PromoStatsFailure.stats:type_name -> promocodes.PromoStats
+	54, // 41: promocodes.PromoStatsFailure.failure:type_name -> common.Failure
+	51, // 42: promocodes.PromoActivation.activatedAt:type_name -> google.protobuf.Timestamp
+	13, // 43: promocodes.PromoActivation.reward:type_name -> promocodes.Reward
+	28, // 44: promocodes.PromoActivations.activations:type_name -> promocodes.PromoActivation
+	29, // 45: promocodes.PromoActivationsFailure.activations:type_name -> promocodes.PromoActivations
+	54, // 46: promocodes.PromoActivationsFailure.failure:type_name -> common.Failure
+	52, // 47: promocodes.Campaign.currency:type_name -> common.Currency
+	51, // 48: promocodes.Campaign.expAt:type_name -> google.protobuf.Timestamp
+	51, // 49: promocodes.Campaign.createdAt:type_name -> google.protobuf.Timestamp
+	52, // 50: promocodes.CreateCampaignIn.currency:type_name -> common.Currency
+	51, // 51: promocodes.CreateCampaignIn.expAt:type_name -> google.protobuf.Timestamp
+	31, // 52: promocodes.CampaignFailure.campaign:type_name -> promocodes.Campaign
+	54, // 53: promocodes.CampaignFailure.failure:type_name -> common.Failure
+	35, // 54: promocodes.GeneratedCodesFailure.codes:type_name -> promocodes.GeneratedCodes
+	54, // 55: promocodes.GeneratedCodesFailure.failure:type_name -> common.Failure
+	38, // 56: promocodes.CampaignCodes.codes:type_name -> promocodes.CampaignCode
+	39, // 57: promocodes.CampaignCodesFailure.codes:type_name -> promocodes.CampaignCodes
+	54, // 58: promocodes.CampaignCodesFailure.failure:type_name -> common.Failure
+	51, // 59: promocodes.Referral.joinedAt:type_name -> google.protobuf.Timestamp
+	41, // 60: promocodes.Referrals.referrals:type_name -> promocodes.Referral
+	42, // 61: promocodes.ReferralsFailure.referrals:type_name -> promocodes.Referrals
+	54, // 62: promocodes.ReferralsFailure.failure:type_name -> common.Failure
+	3,  // 63: promocodes.ImportIn.format:type_name -> promocodes.FileFormat
+	8,  // 64: promocodes.ImportResult.promos:type_name -> promocodes.PromoCode
+	45, // 65: promocodes.ImportResult.errors:type_name -> promocodes.ImportRowError
+	46, // 66: promocodes.ImportResultFailure.result:type_name -> promocodes.ImportResult
+	54, // 67: promocodes.ImportResultFailure.failure:type_name -> common.Failure
+	3,  // 68: promocodes.ExportIn.format:type_name -> promocodes.FileFormat
+	49, // 69: promocodes.ExportFilesFailure.files:type_name -> promocodes.ExportFiles
+	54, // 70: promocodes.ExportFilesFailure.failure:type_name -> common.Failure
+	9,  // 71: promocodes.Promos.Create:input_type -> promocodes.CreatePromo
+	17, // 72: promocodes.Promos.Delete:input_type -> promocodes.PromoUserId
+	18, // 73: promocodes.Promos.DeleteByName:input_type -> promocodes.PromoUserName
+	7,  // 74: promocodes.Promos.DeleteHistory:input_type -> promocodes.PromoId
+	7,  // 75: promocodes.Promos.GetById:input_type -> promocodes.PromoId
+	6,  // 76: promocodes.Promos.GetByName:input_type -> promocodes.PromoName
+	19, // 77: promocodes.Promos.List:input_type -> promocodes.PromoFilter
+	23, // 78: promocodes.Promos.GetStats:input_type -> promocodes.PromoStatsIn
+	27, // 79: promocodes.Promos.GetActivations:input_type -> promocodes.PromoActivationsIn
+	17, // 80: promocodes.Promos.Use:input_type -> promocodes.PromoUserId
+	18, // 81: promocodes.Promos.UseByName:input_type -> promocodes.PromoUserName
+	17, // 82: promocodes.Promos.Pause:input_type -> promocodes.PromoUserId
+	17, // 83: promocodes.Promos.Resume:input_type -> promocodes.PromoUserId
+	32, // 84: promocodes.Promos.CreateCampaign:input_type -> promocodes.CreateCampaignIn
+	34, // 85: promocodes.Promos.GenerateCodes:input_type -> promocodes.GenerateCodesIn
+	37, // 86: promocodes.Promos.ExportCodes:input_type -> promocodes.ExportCodesIn
+	56, // 87: promocodes.Promos.GetReferralCode:input_type -> users.Id
+	56, // 88: promocodes.Promos.GetReferrals:input_type -> users.Id
+	44, // 89: promocodes.Promos.Import:input_type -> promocodes.ImportIn
+	48, // 90: promocodes.Promos.Export:input_type -> promocodes.ExportIn
+	4,  // 91: promocodes.Promos.AddTime:input_type -> promocodes.AddTimeIn
+	5,  // 92: promocodes.Promos.AddUses:input_type -> promocodes.AddUsesIn
+	16, // 93: promocodes.Promos.Create:output_type -> promocodes.PromoFailure
+	57, // 94: promocodes.Promos.Delete:output_type -> common.Response
+	57, // 95: promocodes.Promos.DeleteByName:output_type -> common.Response
+	57, // 96: promocodes.Promos.DeleteHistory:output_type -> common.Response
+	16, // 97: promocodes.Promos.GetById:output_type -> promocodes.PromoFailure
+	16, // 98: promocodes.Promos.GetByName:output_type -> promocodes.PromoFailure
+	22, // 99: promocodes.Promos.List:output_type -> promocodes.AllPromosFailure
+	26, // 100: promocodes.Promos.GetStats:output_type -> promocodes.PromoStatsFailure
+	30, // 101: promocodes.Promos.GetActivations:output_type -> promocodes.PromoActivationsFailure
+	14, // 102: promocodes.Promos.Use:output_type -> promocodes.RewardFailure
+	14, // 103: promocodes.Promos.UseByName:output_type -> promocodes.RewardFailure
+	57, // 104: promocodes.Promos.Pause:output_type -> common.Response
+	57, // 105: promocodes.Promos.Resume:output_type -> common.Response
+	33, // 106: promocodes.Promos.CreateCampaign:output_type -> promocodes.CampaignFailure
+	36, // 107: promocodes.Promos.GenerateCodes:output_type -> promocodes.GeneratedCodesFailure
+	40, // 108: promocodes.Promos.ExportCodes:output_type -> promocodes.CampaignCodesFailure
+	16, // 109: promocodes.Promos.GetReferralCode:output_type -> promocodes.PromoFailure
+	43, // 110: promocodes.Promos.GetReferrals:output_type -> promocodes.ReferralsFailure
+	47, // 111: promocodes.Promos.Import:output_type -> promocodes.ImportResultFailure
+	50, // 112: promocodes.Promos.Export:output_type -> promocodes.ExportFilesFailure
+	57, // 113: promocodes.Promos.AddTime:output_type -> common.Response
+	57, // 114: promocodes.Promos.AddUses:output_type -> common.Response
+	93, // [93:115] is the sub-list for method output_type
+	71, // [71:93] is the sub-list for method input_type
+	71, // [71:71] is the sub-list for extension type_name
+	71, // [71:71] is the sub-list for extension extendee
+	0,  // [0:71] is the sub-list for field type_name
 }
 
 func init() { file_promos_service_proto_init() }
@@ -619,19 +3664,31 @@ func file_promos_service_proto_init() {
 	if File_promos_service_proto != nil {
 		return
 	}
-	file_promos_service_proto_msgTypes[6].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[10].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[12].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[18].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[22].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[26].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[29].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[32].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[36].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[39].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[43].OneofWrappers = []any{}
+	file_promos_service_proto_msgTypes[46].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_promos_service_proto_rawDesc), len(file_promos_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      4,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_promos_service_proto_goTypes,
 		DependencyIndexes: file_promos_service_proto_depIdxs,
+		EnumInfos:         file_promos_service_proto_enumTypes,
 		MessageInfos:      file_promos_service_proto_msgTypes,
 	}.Build()
 	File_promos_service_proto = out.File
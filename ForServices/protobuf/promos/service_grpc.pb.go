@@ -7,11 +7,12 @@
 package promos
 
 import (
-	common "protobuf/common"
 	context "context"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	common "protobuf/common"
+	users "protobuf/users"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -20,14 +21,28 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Promos_Create_FullMethodName        = "/promocodes.Promos/Create"
-	Promos_Delete_FullMethodName        = "/promocodes.Promos/Delete"
-	Promos_DeleteHistory_FullMethodName = "/promocodes.Promos/DeleteHistory"
-	Promos_GetById_FullMethodName       = "/promocodes.Promos/GetById"
-	Promos_GetByName_FullMethodName     = "/promocodes.Promos/GetByName"
-	Promos_Use_FullMethodName           = "/promocodes.Promos/Use"
-	Promos_AddTime_FullMethodName       = "/promocodes.Promos/AddTime"
-	Promos_AddUses_FullMethodName       = "/promocodes.Promos/AddUses"
+	Promos_Create_FullMethodName          = "/promocodes.Promos/Create"
+	Promos_Delete_FullMethodName          = "/promocodes.Promos/Delete"
+	Promos_DeleteByName_FullMethodName    = "/promocodes.Promos/DeleteByName"
+	Promos_DeleteHistory_FullMethodName   = "/promocodes.Promos/DeleteHistory"
+	Promos_GetById_FullMethodName         = "/promocodes.Promos/GetById"
+	Promos_GetByName_FullMethodName       = "/promocodes.Promos/GetByName"
+	Promos_List_FullMethodName            = "/promocodes.Promos/List"
+	Promos_GetStats_FullMethodName        = "/promocodes.Promos/GetStats"
+	Promos_GetActivations_FullMethodName  = "/promocodes.Promos/GetActivations"
+	Promos_Use_FullMethodName             = "/promocodes.Promos/Use"
+	Promos_UseByName_FullMethodName       = "/promocodes.Promos/UseByName"
+	Promos_Pause_FullMethodName           = "/promocodes.Promos/Pause"
+	Promos_Resume_FullMethodName          = "/promocodes.Promos/Resume"
+	Promos_CreateCampaign_FullMethodName  = "/promocodes.Promos/CreateCampaign"
+	Promos_GenerateCodes_FullMethodName   = "/promocodes.Promos/GenerateCodes"
+	Promos_ExportCodes_FullMethodName     = "/promocodes.Promos/ExportCodes"
+	Promos_GetReferralCode_FullMethodName = "/promocodes.Promos/GetReferralCode"
+	Promos_GetReferrals_FullMethodName    = "/promocodes.Promos/GetReferrals"
+	Promos_Import_FullMethodName          = "/promocodes.Promos/Import"
+	Promos_Export_FullMethodName          = "/promocodes.Promos/Export"
+	Promos_AddTime_FullMethodName         = "/promocodes.Promos/AddTime"
+	Promos_AddUses_FullMethodName         = "/promocodes.Promos/AddUses"
 )
 
 // PromosClient is the client API for Promos service.
@@ -36,16 +51,46 @@ const (
 type PromosClient interface {
 	// Check creator role, insert promo to Promos
 	Create(ctx context.Context, in *CreatePromo, opts ...grpc.CallOption) (*PromoFailure, error)
-	// Delete promo from Promos
-	Delete(ctx context.Context, in *PromoId, opts ...grpc.CallOption) (*common.Response, error)
+	// Check creator role, mark promo in Promos as deleted by user. History in UserToPromo kept.
+	// Deleted promo can't be used, its name can be used by new promo.
+	Delete(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error)
+	DeleteByName(ctx context.Context, in *PromoUserName, opts ...grpc.CallOption) (*common.Response, error)
 	// Delete all records from UserToPromo
 	DeleteHistory(ctx context.Context, in *PromoId, opts ...grpc.CallOption) (*common.Response, error)
-	// Get promo from Promos
+	// Get promo from Promos, deleted promo found only by id
 	GetById(ctx context.Context, in *PromoId, opts ...grpc.CallOption) (*PromoFailure, error)
 	GetByName(ctx context.Context, in *PromoName, opts ...grpc.CallOption) (*PromoFailure, error)
-	// Check promo valid (count of uses, expiration data, already activate by user), query to service users
-	Use(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error)
-	// Update expAt of promo in Promos
+	// Get page of promos from Promos, filtered and ordered
+	List(ctx context.Context, in *PromoFilter, opts ...grpc.CallOption) (*AllPromosFailure, error)
+	// Get statistics of activations from UserToPromo
+	GetStats(ctx context.Context, in *PromoStatsIn, opts ...grpc.CallOption) (*PromoStatsFailure, error)
+	// Get page of activations from UserToPromo, newest first
+	GetActivations(ctx context.Context, in *PromoActivationsIn, opts ...grpc.CallOption) (*PromoActivationsFailure, error)
+	// Check promo valid (count of uses, expiration data, already activate by user), query to service users.
+	// Return reward granted to user.
+	Use(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*RewardFailure, error)
+	// Same as Use, but promo found by name, case and extra whitespaces ignored
+	UseByName(ctx context.Context, in *PromoUserName, opts ...grpc.CallOption) (*RewardFailure, error)
+	// Check creator role, update isActive of promo in Promos. Paused promo can't be used.
+	Pause(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error)
+	Resume(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error)
+	// Check creator role, insert campaign to Campaigns
+	CreateCampaign(ctx context.Context, in *CreateCampaignIn, opts ...grpc.CallOption) (*CampaignFailure, error)
+	// Check creator role, insert generated single-use codes of campaign to Promos.
+	// Every redeemed code counts against uses of campaign.
+	GenerateCodes(ctx context.Context, in *GenerateCodesIn, opts ...grpc.CallOption) (*GeneratedCodesFailure, error)
+	// Get page of codes of campaign from Promos
+	ExportCodes(ctx context.Context, in *ExportCodesIn, opts ...grpc.CallOption) (*CampaignCodesFailure, error)
+	// Get personal referral code of user from Promos, insert it if user doesn't have it
+	GetReferralCode(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*PromoFailure, error)
+	// Get users, who joined with referral code of user, from Referrals
+	GetReferrals(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*ReferralsFailure, error)
+	// Check creator role, insert promos from CSV or JSON file to Promos.
+	// Every row checked as in Create, rows with errors skipped and reported.
+	Import(ctx context.Context, in *ImportIn, opts ...grpc.CallOption) (*ImportResultFailure, error)
+	// Check creator role, get promos from Promos and their activations from UserToPromo as CSV or JSON files
+	Export(ctx context.Context, in *ExportIn, opts ...grpc.CallOption) (*ExportFilesFailure, error)
+	// Update expAt and/or startsAt of promo in Promos
 	AddTime(ctx context.Context, in *AddTimeIn, opts ...grpc.CallOption) (*common.Response, error)
 	// Update uses of promo in Promos
 	AddUses(ctx context.Context, in *AddUsesIn, opts ...grpc.CallOption) (*common.Response, error)
@@ -69,7 +114,7 @@ func (c *promosClient) Create(ctx context.Context, in *CreatePromo, opts ...grpc
 	return out, nil
 }
 
-func (c *promosClient) Delete(ctx context.Context, in *PromoId, opts ...grpc.CallOption) (*common.Response, error) {
+func (c *promosClient) Delete(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(common.Response)
 	err := c.cc.Invoke(ctx, Promos_Delete_FullMethodName, in, out, cOpts...)
@@ -79,6 +124,16 @@ func (c *promosClient) Delete(ctx context.Context, in *PromoId, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *promosClient) DeleteByName(ctx context.Context, in *PromoUserName, opts ...grpc.CallOption) (*common.Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.Response)
+	err := c.cc.Invoke(ctx, Promos_DeleteByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *promosClient) DeleteHistory(ctx context.Context, in *PromoId, opts ...grpc.CallOption) (*common.Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(common.Response)
@@ -109,9 +164,39 @@ func (c *promosClient) GetByName(ctx context.Context, in *PromoName, opts ...grp
 	return out, nil
 }
 
-func (c *promosClient) Use(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error) {
+func (c *promosClient) List(ctx context.Context, in *PromoFilter, opts ...grpc.CallOption) (*AllPromosFailure, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(common.Response)
+	out := new(AllPromosFailure)
+	err := c.cc.Invoke(ctx, Promos_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) GetStats(ctx context.Context, in *PromoStatsIn, opts ...grpc.CallOption) (*PromoStatsFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoStatsFailure)
+	err := c.cc.Invoke(ctx, Promos_GetStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) GetActivations(ctx context.Context, in *PromoActivationsIn, opts ...grpc.CallOption) (*PromoActivationsFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoActivationsFailure)
+	err := c.cc.Invoke(ctx, Promos_GetActivations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) Use(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*RewardFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewardFailure)
 	err := c.cc.Invoke(ctx, Promos_Use_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -119,6 +204,106 @@ func (c *promosClient) Use(ctx context.Context, in *PromoUserId, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *promosClient) UseByName(ctx context.Context, in *PromoUserName, opts ...grpc.CallOption) (*RewardFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RewardFailure)
+	err := c.cc.Invoke(ctx, Promos_UseByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) Pause(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.Response)
+	err := c.cc.Invoke(ctx, Promos_Pause_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) Resume(ctx context.Context, in *PromoUserId, opts ...grpc.CallOption) (*common.Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.Response)
+	err := c.cc.Invoke(ctx, Promos_Resume_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) CreateCampaign(ctx context.Context, in *CreateCampaignIn, opts ...grpc.CallOption) (*CampaignFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CampaignFailure)
+	err := c.cc.Invoke(ctx, Promos_CreateCampaign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) GenerateCodes(ctx context.Context, in *GenerateCodesIn, opts ...grpc.CallOption) (*GeneratedCodesFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GeneratedCodesFailure)
+	err := c.cc.Invoke(ctx, Promos_GenerateCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) ExportCodes(ctx context.Context, in *ExportCodesIn, opts ...grpc.CallOption) (*CampaignCodesFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CampaignCodesFailure)
+	err := c.cc.Invoke(ctx, Promos_ExportCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) GetReferralCode(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*PromoFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PromoFailure)
+	err := c.cc.Invoke(ctx, Promos_GetReferralCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) GetReferrals(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*ReferralsFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReferralsFailure)
+	err := c.cc.Invoke(ctx, Promos_GetReferrals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) Import(ctx context.Context, in *ImportIn, opts ...grpc.CallOption) (*ImportResultFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportResultFailure)
+	err := c.cc.Invoke(ctx, Promos_Import_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *promosClient) Export(ctx context.Context, in *ExportIn, opts ...grpc.CallOption) (*ExportFilesFailure, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportFilesFailure)
+	err := c.cc.Invoke(ctx, Promos_Export_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *promosClient) AddTime(ctx context.Context, in *AddTimeIn, opts ...grpc.CallOption) (*common.Response, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(common.Response)
@@ -145,16 +330,46 @@ func (c *promosClient) AddUses(ctx context.Context, in *AddUsesIn, opts ...grpc.
 type PromosServer interface {
 	// Check creator role, insert promo to Promos
 	Create(context.Context, *CreatePromo) (*PromoFailure, error)
-	// Delete promo from Promos
-	Delete(context.Context, *PromoId) (*common.Response, error)
+	// Check creator role, mark promo in Promos as deleted by user. History in UserToPromo kept.
+	// Deleted promo can't be used, its name can be used by new promo.
+	Delete(context.Context, *PromoUserId) (*common.Response, error)
+	DeleteByName(context.Context, *PromoUserName) (*common.Response, error)
 	// Delete all records from UserToPromo
 	DeleteHistory(context.Context, *PromoId) (*common.Response, error)
-	// Get promo from Promos
+	// Get promo from Promos, deleted promo found only by id
 	GetById(context.Context, *PromoId) (*PromoFailure, error)
 	GetByName(context.Context, *PromoName) (*PromoFailure, error)
-	// Check promo valid (count of uses, expiration data, already activate by user), query to service users
-	Use(context.Context, *PromoUserId) (*common.Response, error)
-	// Update expAt of promo in Promos
+	// Get page of promos from Promos, filtered and ordered
+	List(context.Context, *PromoFilter) (*AllPromosFailure, error)
+	// Get statistics of activations from UserToPromo
+	GetStats(context.Context, *PromoStatsIn) (*PromoStatsFailure, error)
+	// Get page of activations from UserToPromo, newest first
+	GetActivations(context.Context, *PromoActivationsIn) (*PromoActivationsFailure, error)
+	// Check promo valid (count of uses, expiration data, already activate by user), query to service users.
+	// Return reward granted to user.
+	Use(context.Context, *PromoUserId) (*RewardFailure, error)
+	// Same as Use, but promo found by name, case and extra whitespaces ignored
+	UseByName(context.Context, *PromoUserName) (*RewardFailure, error)
+	// Check creator role, update isActive of promo in Promos. Paused promo can't be used.
+	Pause(context.Context, *PromoUserId) (*common.Response, error)
+	Resume(context.Context, *PromoUserId) (*common.Response, error)
+	// Check creator role, insert campaign to Campaigns
+	CreateCampaign(context.Context, *CreateCampaignIn) (*CampaignFailure, error)
+	// Check creator role, insert generated single-use codes of campaign to Promos.
+	// Every redeemed code counts against uses of campaign.
+	GenerateCodes(context.Context, *GenerateCodesIn) (*GeneratedCodesFailure, error)
+	// Get page of codes of campaign from Promos
+	ExportCodes(context.Context, *ExportCodesIn) (*CampaignCodesFailure, error)
+	// Get personal referral code of user from Promos, insert it if user doesn't have it
+	GetReferralCode(context.Context, *users.Id) (*PromoFailure, error)
+	// Get users, who joined with referral code of user, from Referrals
+	GetReferrals(context.Context, *users.Id) (*ReferralsFailure, error)
+	// Check creator role, insert promos from CSV or JSON file to Promos.
+	// Every row checked as in Create, rows with errors skipped and reported.
+	Import(context.Context, *ImportIn) (*ImportResultFailure, error)
+	// Check creator role, get promos from Promos and their activations from UserToPromo as CSV or JSON files
+	Export(context.Context, *ExportIn) (*ExportFilesFailure, error)
+	// Update expAt and/or startsAt of promo in Promos
 	AddTime(context.Context, *AddTimeIn) (*common.Response, error)
 	// Update uses of promo in Promos
 	AddUses(context.Context, *AddUsesIn) (*common.Response, error)
@@ -171,9 +386,12 @@ type UnimplementedPromosServer struct{}
 func (UnimplementedPromosServer) Create(context.Context, *CreatePromo) (*PromoFailure, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
 }
-func (UnimplementedPromosServer) Delete(context.Context, *PromoId) (*common.Response, error) {
+func (UnimplementedPromosServer) Delete(context.Context, *PromoUserId) (*common.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedPromosServer) DeleteByName(context.Context, *PromoUserName) (*common.Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteByName not implemented")
+}
 func (UnimplementedPromosServer) DeleteHistory(context.Context, *PromoId) (*common.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteHistory not implemented")
 }
@@ -183,9 +401,48 @@ func (UnimplementedPromosServer) GetById(context.Context, *PromoId) (*PromoFailu
 func (UnimplementedPromosServer) GetByName(context.Context, *PromoName) (*PromoFailure, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByName not implemented")
 }
-func (UnimplementedPromosServer) Use(context.Context, *PromoUserId) (*common.Response, error) {
+func (UnimplementedPromosServer) List(context.Context, *PromoFilter) (*AllPromosFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedPromosServer) GetStats(context.Context, *PromoStatsIn) (*PromoStatsFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
+}
+func (UnimplementedPromosServer) GetActivations(context.Context, *PromoActivationsIn) (*PromoActivationsFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActivations not implemented")
+}
+func (UnimplementedPromosServer) Use(context.Context, *PromoUserId) (*RewardFailure, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Use not implemented")
 }
+func (UnimplementedPromosServer) UseByName(context.Context, *PromoUserName) (*RewardFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UseByName not implemented")
+}
+func (UnimplementedPromosServer) Pause(context.Context, *PromoUserId) (*common.Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedPromosServer) Resume(context.Context, *PromoUserId) (*common.Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedPromosServer) CreateCampaign(context.Context, *CreateCampaignIn) (*CampaignFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCampaign not implemented")
+}
+func (UnimplementedPromosServer) GenerateCodes(context.Context, *GenerateCodesIn) (*GeneratedCodesFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateCodes not implemented")
+}
+func (UnimplementedPromosServer) ExportCodes(context.Context, *ExportCodesIn) (*CampaignCodesFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportCodes not implemented")
+}
+func (UnimplementedPromosServer) GetReferralCode(context.Context, *users.Id) (*PromoFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReferralCode not implemented")
+}
+func (UnimplementedPromosServer) GetReferrals(context.Context, *users.Id) (*ReferralsFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReferrals not implemented")
+}
+func (UnimplementedPromosServer) Import(context.Context, *ImportIn) (*ImportResultFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
+func (UnimplementedPromosServer) Export(context.Context, *ExportIn) (*ExportFilesFailure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 func (UnimplementedPromosServer) AddTime(context.Context, *AddTimeIn) (*common.Response, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTime not implemented")
 }
@@ -232,7 +489,7 @@ func _Promos_Create_Handler(srv interface{}, ctx context.Context, dec func(inter
 }
 
 func _Promos_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PromoId)
+	in := new(PromoUserId)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -244,7 +501,25 @@ func _Promos_Delete_Handler(srv interface{}, ctx context.Context, dec func(inter
 		FullMethod: Promos_Delete_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PromosServer).Delete(ctx, req.(*PromoId))
+		return srv.(PromosServer).Delete(ctx, req.(*PromoUserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_DeleteByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoUserName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).DeleteByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_DeleteByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).DeleteByName(ctx, req.(*PromoUserName))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -303,6 +578,60 @@ func _Promos_GetByName_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Promos_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoFilter)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).List(ctx, req.(*PromoFilter))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoStatsIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).GetStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_GetStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).GetStats(ctx, req.(*PromoStatsIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_GetActivations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoActivationsIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).GetActivations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_GetActivations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).GetActivations(ctx, req.(*PromoActivationsIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Promos_Use_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PromoUserId)
 	if err := dec(in); err != nil {
@@ -321,6 +650,186 @@ func _Promos_Use_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Promos_UseByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoUserName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).UseByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_UseByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).UseByName(ctx, req.(*PromoUserName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoUserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).Pause(ctx, req.(*PromoUserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoUserId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).Resume(ctx, req.(*PromoUserId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_CreateCampaign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCampaignIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).CreateCampaign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_CreateCampaign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).CreateCampaign(ctx, req.(*CreateCampaignIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_GenerateCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateCodesIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).GenerateCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_GenerateCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).GenerateCodes(ctx, req.(*GenerateCodesIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_ExportCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportCodesIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).ExportCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_ExportCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).ExportCodes(ctx, req.(*ExportCodesIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_GetReferralCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(users.Id)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).GetReferralCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_GetReferralCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).GetReferralCode(ctx, req.(*users.Id))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_GetReferrals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(users.Id)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).GetReferrals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_GetReferrals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).GetReferrals(ctx, req.(*users.Id))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_Import_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).Import(ctx, req.(*ImportIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Promos_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PromosServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Promos_Export_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PromosServer).Export(ctx, req.(*ExportIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Promos_AddTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddTimeIn)
 	if err := dec(in); err != nil {
@@ -372,6 +881,10 @@ var Promos_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Promos_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteByName",
+			Handler:    _Promos_DeleteByName_Handler,
+		},
 		{
 			MethodName: "DeleteHistory",
 			Handler:    _Promos_DeleteHistory_Handler,
@@ -384,10 +897,62 @@ var Promos_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetByName",
 			Handler:    _Promos_GetByName_Handler,
 		},
+		{
+			MethodName: "List",
+			Handler:    _Promos_List_Handler,
+		},
+		{
+			MethodName: "GetStats",
+			Handler:    _Promos_GetStats_Handler,
+		},
+		{
+			MethodName: "GetActivations",
+			Handler:    _Promos_GetActivations_Handler,
+		},
 		{
 			MethodName: "Use",
 			Handler:    _Promos_Use_Handler,
 		},
+		{
+			MethodName: "UseByName",
+			Handler:    _Promos_UseByName_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Promos_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Promos_Resume_Handler,
+		},
+		{
+			MethodName: "CreateCampaign",
+			Handler:    _Promos_CreateCampaign_Handler,
+		},
+		{
+			MethodName: "GenerateCodes",
+			Handler:    _Promos_GenerateCodes_Handler,
+		},
+		{
+			MethodName: "ExportCodes",
+			Handler:    _Promos_ExportCodes_Handler,
+		},
+		{
+			MethodName: "GetReferralCode",
+			Handler:    _Promos_GetReferralCode_Handler,
+		},
+		{
+			MethodName: "GetReferrals",
+			Handler:    _Promos_GetReferrals_Handler,
+		},
+		{
+			MethodName: "Import",
+			Handler:    _Promos_Import_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _Promos_Export_Handler,
+		},
 		{
 			MethodName: "AddTime",
 			Handler:    _Promos_AddTime_Handler,
@@ -7,11 +7,12 @@
 package warns
 
 import (
-	common "protobuf/common"
-	users "protobuf/users"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	common "protobuf/common"
+	users "protobuf/users"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -264,6 +265,8 @@ type Ban struct {
 	Reason        *string                `protobuf:"bytes,4,opt,name=Reason,proto3,oneof" json:"Reason,omitempty"`
 	IssuedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=IssuedAt,proto3" json:"IssuedAt,omitempty"`
 	IsActive      bool                   `protobuf:"varint,6,opt,name=IsActive,proto3" json:"IsActive,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"` // Empty for permanent ban
+	Remaining     *durationpb.Duration   `protobuf:"bytes,8,opt,name=Remaining,proto3" json:"Remaining,omitempty"` // Time until expiration, only for active ban with expiration
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -340,6 +343,20 @@ func (x *Ban) GetIsActive() bool {
 	return false
 }
 
+func (x *Ban) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Ban) GetRemaining() *durationpb.Duration {
+	if x != nil {
+		return x.Remaining
+	}
+	return nil
+}
+
 type BanFailure struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Ban           *Ban                   `protobuf:"bytes,1,opt,name=ban,proto3,oneof" json:"ban,omitempty"`
@@ -545,6 +562,8 @@ type ModerUserReason struct {
 	UserId        int64                  `protobuf:"varint,1,opt,name=UserId,proto3" json:"UserId,omitempty"`
 	ModerId       int64                  `protobuf:"varint,2,opt,name=ModerId,proto3" json:"ModerId,omitempty"`
 	Reason        *string                `protobuf:"bytes,3,opt,name=Reason,proto3,oneof" json:"Reason,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=ExpiresAt,proto3" json:"ExpiresAt,omitempty"` // Optional, only for Ban, empty for permanent ban
+	Duration      *durationpb.Duration   `protobuf:"bytes,5,opt,name=Duration,proto3" json:"Duration,omitempty"`   // Optional, only for Ban, used if ExpiresAt is empty
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -600,11 +619,25 @@ func (x *ModerUserReason) GetReason() string {
 	return ""
 }
 
+func (x *ModerUserReason) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ModerUserReason) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
 var File_warns_service_proto protoreflect.FileDescriptor
 
 const file_warns_service_proto_rawDesc = "" +
 	"\n" +
-	"\x13warns/service.proto\x12\x05warns\x1a\x12common/types.proto\x1a\x13users/service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc4\x01\n" +
+	"\x13warns/service.proto\x12\x05warns\x1a\x12common/types.proto\x1a\x13users/service.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\"\xc4\x01\n" +
 	"\x04Warn\x12\x0e\n" +
 	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12\x16\n" +
 	"\x06UserId\x18\x02 \x01(\x03R\x06UserId\x12\x18\n" +
@@ -626,14 +659,16 @@ const file_warns_service_proto_rawDesc = "" +
 	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x01R\afailure\x88\x01\x01B\b\n" +
 	"\x06_warnsB\n" +
 	"\n" +
-	"\b_failure\"\xc3\x01\n" +
+	"\b_failure\"\xb6\x02\n" +
 	"\x03Ban\x12\x0e\n" +
 	"\x02Id\x18\x01 \x01(\x03R\x02Id\x12\x16\n" +
 	"\x06UserId\x18\x02 \x01(\x03R\x06UserId\x12\x18\n" +
 	"\aModerId\x18\x03 \x01(\x03R\aModerId\x12\x1b\n" +
 	"\x06Reason\x18\x04 \x01(\tH\x00R\x06Reason\x88\x01\x01\x126\n" +
 	"\bIssuedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bIssuedAt\x12\x1a\n" +
-	"\bIsActive\x18\x06 \x01(\bR\bIsActiveB\t\n" +
+	"\bIsActive\x18\x06 \x01(\bR\bIsActive\x128\n" +
+	"\tExpiresAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tExpiresAt\x127\n" +
+	"\tRemaining\x18\b \x01(\v2\x19.google.protobuf.DurationR\tRemainingB\t\n" +
 	"\a_Reason\"s\n" +
 	"\n" +
 	"BanFailure\x12!\n" +
@@ -658,11 +693,13 @@ const file_warns_service_proto_rawDesc = "" +
 	"countWarns\x12.\n" +
 	"\afailure\x18\x02 \x01(\v2\x0f.common.FailureH\x00R\afailure\x88\x01\x01B\n" +
 	"\n" +
-	"\b_failure\"k\n" +
+	"\b_failure\"\xdc\x01\n" +
 	"\x0fModerUserReason\x12\x16\n" +
 	"\x06UserId\x18\x01 \x01(\x03R\x06UserId\x12\x18\n" +
 	"\aModerId\x18\x02 \x01(\x03R\aModerId\x12\x1b\n" +
-	"\x06Reason\x18\x03 \x01(\tH\x00R\x06Reason\x88\x01\x01B\t\n" +
+	"\x06Reason\x18\x03 \x01(\tH\x00R\x06Reason\x88\x01\x01\x128\n" +
+	"\tExpiresAt\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tExpiresAt\x125\n" +
+	"\bDuration\x18\x05 \x01(\v2\x19.google.protobuf.DurationR\bDurationB\t\n" +
 	"\a_Reason2\x9b\x04\n" +
 	"\x05Warns\x122\n" +
 	"\x04Warn\x12\x16.warns.ModerUserReason\x1a\x12.warns.WarnFailure\x125\n" +
@@ -703,8 +740,9 @@ var file_warns_service_proto_goTypes = []any{
 	(*ModerUserReason)(nil),       // 9: warns.ModerUserReason
 	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
 	(*common.Failure)(nil),        // 11: common.Failure
-	(*users.Id)(nil),              // 12: users.Id
-	(*common.Response)(nil),       // 13: common.Response
+	(*durationpb.Duration)(nil),   // 12: google.protobuf.Duration
+	(*users.Id)(nil),              // 13: users.Id
+	(*common.Response)(nil),       // 14: common.Response
 }
 var file_warns_service_proto_depIdxs = []int32{
 	10, // 0: warns.Warn.IssuedAt:type_name -> google.protobuf.Timestamp
@@ -714,37 +752,41 @@ var file_warns_service_proto_depIdxs = []int32{
 	2,  // 4: warns.AllWarnsFailure.warns:type_name -> warns.AllWarns
 	11, // 5: warns.AllWarnsFailure.failure:type_name -> common.Failure
 	10, // 6: warns.Ban.IssuedAt:type_name -> google.protobuf.Timestamp
-	4,  // 7: warns.BanFailure.ban:type_name -> warns.Ban
-	11, // 8: warns.BanFailure.failure:type_name -> common.Failure
-	4,  // 9: warns.AllBans.bans:type_name -> warns.Ban
-	6,  // 10: warns.AllBansFailure.bans:type_name -> warns.AllBans
-	11, // 11: warns.AllBansFailure.failure:type_name -> common.Failure
-	11, // 12: warns.CountOfActiveWarns.failure:type_name -> common.Failure
-	9,  // 13: warns.Warns.Warn:input_type -> warns.ModerUserReason
-	9,  // 14: warns.Warns.AllUnWarn:input_type -> warns.ModerUserReason
-	9,  // 15: warns.Warns.LastUnWarn:input_type -> warns.ModerUserReason
-	9,  // 16: warns.Warns.Ban:input_type -> warns.ModerUserReason
-	9,  // 17: warns.Warns.Unban:input_type -> warns.ModerUserReason
-	12, // 18: warns.Warns.GetHistoryWarns:input_type -> users.Id
-	12, // 19: warns.Warns.GetHistoryBans:input_type -> users.Id
-	12, // 20: warns.Warns.GetActiveWarns:input_type -> users.Id
-	12, // 21: warns.Warns.GetActiveBan:input_type -> users.Id
-	12, // 22: warns.Warns.GetCountOfActiveWarns:input_type -> users.Id
-	1,  // 23: warns.Warns.Warn:output_type -> warns.WarnFailure
-	13, // 24: warns.Warns.AllUnWarn:output_type -> common.Response
-	13, // 25: warns.Warns.LastUnWarn:output_type -> common.Response
-	5,  // 26: warns.Warns.Ban:output_type -> warns.BanFailure
-	13, // 27: warns.Warns.Unban:output_type -> common.Response
-	3,  // 28: warns.Warns.GetHistoryWarns:output_type -> warns.AllWarnsFailure
-	7,  // 29: warns.Warns.GetHistoryBans:output_type -> warns.AllBansFailure
-	3,  // 30: warns.Warns.GetActiveWarns:output_type -> warns.AllWarnsFailure
-	5,  // 31: warns.Warns.GetActiveBan:output_type -> warns.BanFailure
-	8,  // 32: warns.Warns.GetCountOfActiveWarns:output_type -> warns.CountOfActiveWarns
-	23, // [23:33] is the sub-list for method output_type
-	13, // [13:23] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	10, // 7: warns.Ban.ExpiresAt:type_name -> google.protobuf.Timestamp
+	12, // 8: warns.Ban.Remaining:type_name -> google.protobuf.Duration
+	4,  // 9: warns.BanFailure.ban:type_name -> warns.Ban
+	11, // 10: warns.BanFailure.failure:type_name -> common.Failure
+	4,  // 11: warns.AllBans.bans:type_name -> warns.Ban
+	6,  // 12: warns.AllBansFailure.bans:type_name -> warns.AllBans
+	11, // 13: warns.AllBansFailure.failure:type_name -> common.Failure
+	11, // 14: warns.CountOfActiveWarns.failure:type_name -> common.Failure
+	10, // 15: warns.ModerUserReason.ExpiresAt:type_name -> google.protobuf.Timestamp
+	12, // 16: warns.ModerUserReason.Duration:type_name -> google.protobuf.Duration
+	9,  // 17: warns.Warns.Warn:input_type -> warns.ModerUserReason
+	9,  // 18: warns.Warns.AllUnWarn:input_type -> warns.ModerUserReason
+	9,  // 19: warns.Warns.LastUnWarn:input_type -> warns.ModerUserReason
+	9,  // 20: warns.Warns.Ban:input_type -> warns.ModerUserReason
+	9,  // 21: warns.Warns.Unban:input_type -> warns.ModerUserReason
+	13, // 22: warns.Warns.GetHistoryWarns:input_type -> users.Id
+	13, // 23: warns.Warns.GetHistoryBans:input_type -> users.Id
+	13, // 24: warns.Warns.GetActiveWarns:input_type -> users.Id
+	13, // 25: warns.Warns.GetActiveBan:input_type -> users.Id
+	13, // 26: warns.Warns.GetCountOfActiveWarns:input_type -> users.Id
+	1,  // 27: warns.Warns.Warn:output_type -> warns.WarnFailure
+	14, // 28: warns.Warns.AllUnWarn:output_type -> common.Response
+	14, // 29: warns.Warns.LastUnWarn:output_type -> common.Response
+	5,  // 30: warns.Warns.Ban:output_type -> warns.BanFailure
+	14, // 31: warns.Warns.Unban:output_type -> common.Response
+	3,  // 32: warns.Warns.GetHistoryWarns:output_type -> warns.AllWarnsFailure
+	7,  // 33: warns.Warns.GetHistoryBans:output_type -> warns.AllBansFailure
+	3,  // 34: warns.Warns.GetActiveWarns:output_type -> warns.AllWarnsFailure
+	5,  // 35: warns.Warns.GetActiveBan:output_type -> warns.BanFailure
+	8,  // 36: warns.Warns.GetCountOfActiveWarns:output_type -> warns.CountOfActiveWarns
+	27, // [27:37] is the sub-list for method output_type
+	17, // [17:27] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_warns_service_proto_init() }
@@ -7,12 +7,12 @@
 package warns
 
 import (
-	common "protobuf/common"
-	users "protobuf/users"
 	context "context"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	common "protobuf/common"
+	users "protobuf/users"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -43,7 +43,8 @@ type WarnsClient interface {
 	AllUnWarn(ctx context.Context, in *ModerUserReason, opts ...grpc.CallOption) (*common.Response, error)
 	// Make last warn for this user inactive
 	LastUnWarn(ctx context.Context, in *ModerUserReason, opts ...grpc.CallOption) (*common.Response, error)
-	// Make all warns for this user inactive, insert active ban in table Bans, set role banned
+	// Make all warns for this user inactive, insert active ban in table Bans, set role banned.
+	// Ban with expiration made inactive automatically, role user set back.
 	Ban(ctx context.Context, in *ModerUserReason, opts ...grpc.CallOption) (*BanFailure, error)
 	// Make ban for this user inactive, set role user
 	Unban(ctx context.Context, in *ModerUserReason, opts ...grpc.CallOption) (*common.Response, error)
@@ -53,7 +54,7 @@ type WarnsClient interface {
 	GetHistoryBans(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*AllBansFailure, error)
 	// Get active warns for this user
 	GetActiveWarns(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*AllWarnsFailure, error)
-	// Get active ban for this user
+	// Get active ban for this user with remaining time
 	GetActiveBan(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*BanFailure, error)
 	// Get count of active warns for this user
 	GetCountOfActiveWarns(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*CountOfActiveWarns, error)
@@ -177,7 +178,8 @@ type WarnsServer interface {
 	AllUnWarn(context.Context, *ModerUserReason) (*common.Response, error)
 	// Make last warn for this user inactive
 	LastUnWarn(context.Context, *ModerUserReason) (*common.Response, error)
-	// Make all warns for this user inactive, insert active ban in table Bans, set role banned
+	// Make all warns for this user inactive, insert active ban in table Bans, set role banned.
+	// Ban with expiration made inactive automatically, role user set back.
 	Ban(context.Context, *ModerUserReason) (*BanFailure, error)
 	// Make ban for this user inactive, set role user
 	Unban(context.Context, *ModerUserReason) (*common.Response, error)
@@ -187,7 +189,7 @@ type WarnsServer interface {
 	GetHistoryBans(context.Context, *users.Id) (*AllBansFailure, error)
 	// Get active warns for this user
 	GetActiveWarns(context.Context, *users.Id) (*AllWarnsFailure, error)
-	// Get active ban for this user
+	// Get active ban for this user with remaining time
 	GetActiveBan(context.Context, *users.Id) (*BanFailure, error)
 	// Get count of active warns for this user
 	GetCountOfActiveWarns(context.Context, *users.Id) (*CountOfActiveWarns, error)
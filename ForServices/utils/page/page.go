@@ -0,0 +1,57 @@
+package page
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	e "errorspomka"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Position of last row on page for keyset pagination, rows ordered by time and id
+type Cursor struct {
+	Time time.Time
+	Id   int64
+}
+
+// Return token of next page, which starts after row with this time and id
+func Encode(c Cursor) string {
+	return base64.RawURLEncoding.EncodeToString(fmt.Appendf(nil, "%d:%d", c.Time.UnixMicro(), c.Id))
+}
+
+// Return cursor from token of page. Empty token is first page, cursor is nil.
+func Decode(token string) (*Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, e.ErrBadPageToken
+	}
+
+	var micro, id int64
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &micro, &id); err != nil {
+		return nil, e.ErrBadPageToken
+	}
+
+	return &Cursor{Time: time.UnixMicro(micro).UTC(), Id: id}, nil
+}
+
+// Return size of page, 0 is default size
+func Limit(limit int32) (int32, error) {
+	if limit < 0 || limit > MaxLimit {
+		return 0, e.ErrBadPageLimit
+	}
+
+	if limit == 0 {
+		return DefaultLimit, nil
+	}
+
+	return limit, nil
+}
@@ -0,0 +1,47 @@
+package signer
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+
+	e "errorspomka"
+)
+
+// Length of signature in payload, truncated HMAC-SHA256
+const signatureLen = 16
+
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+// Return compact url-safe payload with data and its signature
+func (s *Signer) Sign(data []byte) string {
+	payload := append(append([]byte{}, data...), s.signature(data)...)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// Return data from payload, if signature of payload is valid
+func (s *Signer) Verify(payload string) ([]byte, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(raw) <= signatureLen {
+		return nil, e.ErrBadSignature
+	}
+
+	data, signature := raw[:len(raw)-signatureLen], raw[len(raw)-signatureLen:]
+	if !hmac.Equal(signature, s.signature(data)) {
+		return nil, e.ErrBadSignature
+	}
+
+	return data, nil
+}
+
+func (s *Signer) signature(data []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(data)
+	return mac.Sum(nil)[:signatureLen]
+}
@@ -0,0 +1,164 @@
+// Command promoscli imports promos to service promos from CSV or JSON file and exports promos with their activations.
+//
+//	promoscli import -user 1 -format csv promos.csv
+//	promoscli export -user 1 -format json -creator 1 -out ./export
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"protobuf/promos"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const usage = `usage:
+  promoscli import [-addr host:port] -user id [-format csv|json] file
+  promoscli export [-addr host:port] -user id [-format csv|json] [-creator id] [-out dir]`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "import":
+		err = runImport(os.Args[2:])
+	case "export":
+		err = runExport(os.Args[2:])
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
+}
+
+// Flags of both subcommands
+type options struct {
+	addr   string
+	user   int64
+	format string
+}
+
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.addr, "addr", "localhost:"+os.Getenv("SERVICE_PROMOS_PORT"), "address of service promos")
+	fs.Int64Var(&o.user, "user", 0, "id of user with role creator")
+	fs.StringVar(&o.format, "format", "csv", "format of files: csv or json")
+}
+
+func (o *options) fileFormat() (promos.FileFormat, error) {
+	format, ok := promos.FileFormat_value[strings.ToUpper(o.format)]
+	if !ok {
+		return 0, fmt.Errorf("unknown format %q", o.format)
+	}
+
+	return promos.FileFormat(format), nil
+}
+
+func runImport(args []string) error {
+	var opts options
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	opts.register(fs)
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return fmt.Errorf("expected one file\n%s", usage)
+	}
+	format, err := opts.fileFormat()
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	client, closeConn, err := newClient(opts.addr)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := client.Import(ctx, &promos.ImportIn{UserId: opts.user, Format: format, Data: data})
+	if err != nil {
+		return err
+	}
+
+	// Report of import, every row with error on own line
+	result := out.GetResult()
+	for _, rowError := range result.GetErrors() {
+		fmt.Printf("row %d: %s\n", rowError.Row, rowError.Error)
+	}
+	fmt.Printf("imported %d promos, %d rows with errors\n", len(result.GetPromos()), len(result.GetErrors()))
+
+	return nil
+}
+
+func runExport(args []string) error {
+	var opts options
+	var creator int64
+	var dir string
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	opts.register(fs)
+	fs.Int64Var(&creator, "creator", 0, "export only promos of creator, 0 for all")
+	fs.StringVar(&dir, "out", ".", "directory for exported files")
+	fs.Parse(args)
+
+	format, err := opts.fileFormat()
+	if err != nil {
+		return err
+	}
+
+	client, closeConn, err := newClient(opts.addr)
+	if err != nil {
+		return err
+	}
+	defer closeConn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	out, err := client.Export(ctx, &promos.ExportIn{UserId: opts.user, Format: format, Creator: creator})
+	if err != nil {
+		return err
+	}
+
+	ext := "." + strings.ToLower(opts.format)
+	for name, data := range map[string][]byte{
+		"promos" + ext:      out.GetFiles().GetPromos(),
+		"activations" + ext: out.GetFiles().GetActivations(),
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		fmt.Println("wrote", path)
+	}
+
+	return nil
+}
+
+func newClient(addr string) (promos.PromosClient, func() error, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return promos.NewPromosClient(conn), conn.Close, nil
+}
@@ -10,6 +10,7 @@ import (
 	"postgres"
 	"promos/internal/repository"
 	service "promos/internal/transport/grpc/handlers"
+	"protobuf/common"
 	"protobuf/promos"
 	"server"
 
@@ -53,7 +54,14 @@ func Run() {
 
 	// Register service promos
 	grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(logger.LoggingUnaryInterceptor))
-	service := service.NewServicePromos(repo, pool, clientServices)
+	service := service.NewServicePromos(repo, pool,
+		service.Config{
+			Referral: service.Referral{
+				Currency:    common.Currency(cfg.Storage.PromosReferralCurrency),
+				Reward:      cfg.Storage.PromosReferralReward,
+				OwnerReward: cfg.Storage.PromosReferralOwnerReward,
+			},
+		}, clientServices)
 	promos.RegisterPromosServer(grpcSrv, service)
 
 	// Run server
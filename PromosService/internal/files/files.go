@@ -0,0 +1,432 @@
+package files
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	e "errorspomka"
+	"fmt"
+	"protobuf/common"
+	"protobuf/promos"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Max count of rows in imported file
+const maxRows = 10000
+
+// Columns of promo in imported file, exported file has columns of promo after them
+var (
+	importColumns     = []string{"name", "amount", "currency", "uses", "expAt", "startsAt", "userLimit", "cooldown", "rewards", "audience"}
+	exportColumns     = append(append([]string{}, importColumns...), "id", "creator", "createdAt", "isActive", "deletedAt", "deletedBy")
+	activationColumns = []string{"promoId", "userId", "activatedAt", "currency", "amount"}
+)
+
+// Row of imported file, number of row from 1 without header
+type Row struct {
+	Number int32
+	Promo  *promos.CreatePromo
+}
+
+// Promo in file, fields of export are empty in imported file.
+// Reward table and audience are JSON of their messages, in CSV too.
+type promoRow struct {
+	Name      string          `json:"name"`
+	Amount    int64           `json:"amount"`
+	Currency  string          `json:"currency"`
+	Uses      int32           `json:"uses"`
+	ExpAt     string          `json:"expAt"`
+	StartsAt  string          `json:"startsAt,omitempty"`
+	UserLimit int32           `json:"userLimit,omitempty"`
+	Cooldown  string          `json:"cooldown,omitempty"`
+	Rewards   json.RawMessage `json:"rewards,omitempty"`
+	Audience  json.RawMessage `json:"audience,omitempty"`
+
+	Id        int64  `json:"id,omitempty"`
+	Creator   int64  `json:"creator,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	IsActive  *bool  `json:"isActive,omitempty"`
+	DeletedAt string `json:"deletedAt,omitempty"`
+	DeletedBy int64  `json:"deletedBy,omitempty"`
+}
+
+type activationRow struct {
+	PromoId     int64  `json:"promoId"`
+	UserId      int64  `json:"userId"`
+	ActivatedAt string `json:"activatedAt"`
+	Currency    string `json:"currency"`
+	Amount      int64  `json:"amount"`
+}
+
+// Parse promos from file. Rows, which can't be parsed, returned as errors, other rows returned for creating.
+func ParsePromos(format promos.FileFormat, data []byte) ([]Row, []*promos.ImportRowError, error) {
+	var rows []*promoRow
+	var rowErrors []*promos.ImportRowError
+	var err error
+
+	switch format {
+	case promos.FileFormat_CSV:
+		rows, rowErrors, err = parseCSV(data)
+	case promos.FileFormat_JSON:
+		rows, rowErrors, err = parseJSON(data)
+	default:
+		err = e.ErrFileFormat
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var out []Row
+	for i, row := range rows {
+		// Row, which can't be parsed, is nil
+		if row == nil {
+			continue
+		}
+
+		promo, err := row.createPromo()
+		if err != nil {
+			rowErrors = append(rowErrors, &promos.ImportRowError{Row: int32(i + 1), Error: err.Error()})
+			continue
+		}
+
+		out = append(out, Row{Number: int32(i + 1), Promo: promo})
+	}
+
+	return out, rowErrors, nil
+}
+
+// Encode promos to file
+func EncodePromos(format promos.FileFormat, in []*promos.PromoCode) ([]byte, error) {
+	var rows = make([]promoRow, 0, len(in))
+	for _, promo := range in {
+		row, err := newPromoRow(promo)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	switch format {
+	case promos.FileFormat_CSV:
+		var records = make([][]string, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, row.record())
+		}
+		return encodeCSV(exportColumns, records)
+	case promos.FileFormat_JSON:
+		return json.MarshalIndent(rows, "", "  ")
+	default:
+		return nil, e.ErrFileFormat
+	}
+}
+
+// Encode activations of promos to file
+func EncodeActivations(format promos.FileFormat, in []*promos.PromoActivation) ([]byte, error) {
+	var rows = make([]activationRow, 0, len(in))
+	for _, activation := range in {
+		rows = append(rows, activationRow{
+			PromoId:     activation.PromoId,
+			UserId:      activation.UserId,
+			ActivatedAt: activation.ActivatedAt.AsTime().Format(time.RFC3339),
+			Currency:    activation.Reward.GetCurrency().String(),
+			Amount:      activation.Reward.GetAmount(),
+		})
+	}
+
+	switch format {
+	case promos.FileFormat_CSV:
+		var records = make([][]string, 0, len(rows))
+		for _, row := range rows {
+			records = append(records, []string{
+				strconv.FormatInt(row.PromoId, 10),
+				strconv.FormatInt(row.UserId, 10),
+				row.ActivatedAt,
+				row.Currency,
+				strconv.FormatInt(row.Amount, 10),
+			})
+		}
+		return encodeCSV(activationColumns, records)
+	case promos.FileFormat_JSON:
+		return json.MarshalIndent(rows, "", "  ")
+	default:
+		return nil, e.ErrFileFormat
+	}
+}
+
+// Parse CSV with header, columns found by names, unknown columns ignored
+func parseCSV(data []byte) ([]*promoRow, []*promos.ImportRowError, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, nil, errors.Join(e.ErrFileFormat, err)
+	}
+	if len(records) < 2 || len(records)-1 > maxRows {
+		return nil, nil, e.ErrFileFormat
+	}
+
+	var index = make(map[string]int, len(records[0]))
+	for i, column := range records[0] {
+		index[column] = i
+	}
+	for _, column := range []string{"name", "amount", "uses", "expAt"} {
+		if _, ok := index[column]; !ok {
+			return nil, nil, fmt.Errorf("%w: missing column %s", e.ErrFileFormat, column)
+		}
+	}
+
+	var rows = make([]*promoRow, len(records)-1)
+	var rowErrors []*promos.ImportRowError
+	for i, record := range records[1:] {
+		value := func(column string) string {
+			if j, ok := index[column]; ok && j < len(record) {
+				return record[j]
+			}
+			return ""
+		}
+
+		row, err := newPromoRowFromCSV(value)
+		if err != nil {
+			rowErrors = append(rowErrors, &promos.ImportRowError{Row: int32(i + 1), Error: err.Error()})
+			continue
+		}
+		rows[i] = row
+	}
+
+	return rows, rowErrors, nil
+}
+
+// Parse JSON array, every object parsed alone, so wrong object doesn't break others
+func parseJSON(data []byte) ([]*promoRow, []*promos.ImportRowError, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, nil, errors.Join(e.ErrFileFormat, err)
+	}
+	if len(objects) == 0 || len(objects) > maxRows {
+		return nil, nil, e.ErrFileFormat
+	}
+
+	var rows = make([]*promoRow, len(objects))
+	var rowErrors []*promos.ImportRowError
+	for i, object := range objects {
+		var row = new(promoRow)
+		if err := json.Unmarshal(object, row); err != nil {
+			rowErrors = append(rowErrors, &promos.ImportRowError{Row: int32(i + 1), Error: err.Error()})
+			continue
+		}
+		rows[i] = row
+	}
+
+	return rows, rowErrors, nil
+}
+
+func encodeCSV(header []string, records [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Parse columns of import from CSV record, empty optional columns are zero
+func newPromoRowFromCSV(value func(column string) string) (*promoRow, error) {
+	var row = &promoRow{
+		Name:     value("name"),
+		Currency: value("currency"),
+		ExpAt:    value("expAt"),
+		StartsAt: value("startsAt"),
+		Cooldown: value("cooldown"),
+	}
+
+	if v := value("rewards"); v != "" {
+		row.Rewards = json.RawMessage(v)
+	}
+	if v := value("audience"); v != "" {
+		row.Audience = json.RawMessage(v)
+	}
+
+	amount, err := strconv.ParseInt(value("amount"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("amount: %w", err)
+	}
+	row.Amount = amount
+
+	uses, err := strconv.ParseInt(value("uses"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("uses: %w", err)
+	}
+	row.Uses = int32(uses)
+
+	if v := value("userLimit"); v != "" {
+		userLimit, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("userLimit: %w", err)
+		}
+		row.UserLimit = int32(userLimit)
+	}
+
+	return row, nil
+}
+
+func newPromoRow(promo *promos.PromoCode) (promoRow, error) {
+	var row = promoRow{
+		Name:      promo.Name,
+		Amount:    promo.Amount,
+		Currency:  promo.Currency.String(),
+		Uses:      promo.Uses,
+		ExpAt:     promo.ExpAt.AsTime().Format(time.RFC3339),
+		UserLimit: promo.UserLimit,
+		Id:        promo.Id,
+		Creator:   promo.Creator,
+		CreatedAt: promo.CreatedAt.AsTime().Format(time.RFC3339),
+		IsActive:  &promo.IsActive,
+	}
+
+	if promo.StartsAt != nil {
+		row.StartsAt = promo.StartsAt.AsTime().Format(time.RFC3339)
+	}
+	if promo.Cooldown != nil {
+		row.Cooldown = promo.Cooldown.AsDuration().String()
+	}
+	if promo.DeletedAt != nil {
+		row.DeletedAt = promo.DeletedAt.AsTime().Format(time.RFC3339)
+		row.DeletedBy = promo.DeletedBy
+	}
+
+	var err error
+	if len(promo.Rewards.GetTiers()) > 0 || len(promo.Rewards.GetOptions()) > 0 {
+		if row.Rewards, err = marshalMessage(promo.Rewards); err != nil {
+			return promoRow{}, err
+		}
+	}
+	if promo.Audience != nil {
+		if row.Audience, err = marshalMessage(promo.Audience); err != nil {
+			return promoRow{}, err
+		}
+	}
+
+	return row, nil
+}
+
+// Encode message to compact JSON, output of protojson isn't stable by spaces
+func marshalMessage(m proto.Message) (json.RawMessage, error) {
+	data, err := protojson.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Values of row in order of columns of export
+func (row promoRow) record() []string {
+	var isActive, deletedBy string
+	if row.IsActive != nil {
+		isActive = strconv.FormatBool(*row.IsActive)
+	}
+	if row.DeletedBy != 0 {
+		deletedBy = strconv.FormatInt(row.DeletedBy, 10)
+	}
+
+	return []string{
+		row.Name,
+		strconv.FormatInt(row.Amount, 10),
+		row.Currency,
+		strconv.FormatInt(int64(row.Uses), 10),
+		row.ExpAt,
+		row.StartsAt,
+		strconv.FormatInt(int64(row.UserLimit), 10),
+		row.Cooldown,
+		string(row.Rewards),
+		string(row.Audience),
+		strconv.FormatInt(row.Id, 10),
+		strconv.FormatInt(row.Creator, 10),
+		row.CreatedAt,
+		isActive,
+		row.DeletedAt,
+		deletedBy,
+	}
+}
+
+// Convert row to promo for creating, creator set by importer
+func (row promoRow) createPromo() (*promos.CreatePromo, error) {
+	var out = &promos.CreatePromo{
+		Name:      row.Name,
+		Amount:    row.Amount,
+		Uses:      row.Uses,
+		UserLimit: row.UserLimit,
+	}
+
+	if row.Name == "" {
+		return nil, e.ErrMissingPromoName
+	}
+
+	// Currency by name or by number, empty is none
+	if row.Currency != "" {
+		currency, ok := common.Currency_value[row.Currency]
+		if !ok {
+			n, err := strconv.ParseInt(row.Currency, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("currency: %w", e.ErrBadArgs)
+			}
+			currency = int32(n)
+		}
+		out.Currency = common.Currency(currency)
+	}
+
+	expAt, err := time.Parse(time.RFC3339, row.ExpAt)
+	if err != nil {
+		return nil, fmt.Errorf("expAt: %w", err)
+	}
+	out.ExpAt = timestamppb.New(expAt)
+
+	if row.StartsAt != "" {
+		startsAt, err := time.Parse(time.RFC3339, row.StartsAt)
+		if err != nil {
+			return nil, fmt.Errorf("startsAt: %w", err)
+		}
+		out.StartsAt = timestamppb.New(startsAt)
+	}
+
+	if row.Cooldown != "" {
+		cooldown, err := time.ParseDuration(row.Cooldown)
+		if err != nil {
+			return nil, fmt.Errorf("cooldown: %w", err)
+		}
+		out.Cooldown = durationpb.New(cooldown)
+	}
+
+	if len(row.Rewards) > 0 {
+		out.Rewards = new(promos.RewardTable)
+		if err := protojson.Unmarshal(row.Rewards, out.Rewards); err != nil {
+			return nil, fmt.Errorf("rewards: %w", err)
+		}
+	}
+
+	if len(row.Audience) > 0 {
+		out.Audience = new(promos.Audience)
+		if err := protojson.Unmarshal(row.Audience, out.Audience); err != nil {
+			return nil, fmt.Errorf("audience: %w", err)
+		}
+	}
+
+	return out, nil
+}
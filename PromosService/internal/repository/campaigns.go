@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	e "errorspomka"
+	"math/big"
+	"postgres"
+	"protobuf/promos"
+	"time"
+	"utils/page"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Without symbols, which are easy to confuse: 0/O, 1/I
+	defaultCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	defaultCodeLength   = 12
+
+	maxCodesCount = 10000
+
+	// Attempts to generate codes with unique names, small alphabet and length give many conflicts
+	maxGenerateAttempts = 10
+)
+
+// Insert campaign to table campaigns
+func (r *Repository) CreateCampaign(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.CreateCampaignIn) (*promos.Campaign, error) {
+
+	// Check args valid, because Exec() send panic if have error
+	if !(0 <= in.Currency && in.Currency <= 2 && in.Amount >= 0 && (in.Uses > 0 || in.Uses == -1)) {
+		return nil, e.ErrBadArgs
+	}
+
+	q := `INSERT INTO "Campaigns" ("Name", "Currency", "Amount", "Uses", "Creator", "ExpAt")
+	      VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`
+	expAt := in.ExpAt.AsTime().Format("2006-01-02 15:04:05")
+
+	out, err := scanCampaign(db.QueryRow(ctx, q, in.Name, in.Currency, in.Amount, in.Uses, in.Creator, expAt))
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+
+	return out, nil
+}
+
+// Get campaign from table campaigns by ID
+func (r *Repository) GetCampaignById(
+	ctx context.Context,
+	db postgres.DB,
+	id int64) (*promos.Campaign, error) {
+
+	q := `SELECT * FROM "Campaigns"
+	      WHERE "Id" = $1`
+
+	out, err := scanCampaign(db.QueryRow(ctx, q, id))
+	if err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, errors.Join(e.ErrMissingCampaignId, err)
+		default:
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	return out, nil
+}
+
+// Insert generated single-use codes of campaign to table promos. Return codes.
+func (r *Repository) GenerateCampaignCodes(
+	ctx context.Context,
+	db postgres.DB,
+	campaign *promos.Campaign,
+	in *promos.GenerateCodesIn) ([]string, error) {
+
+	alphabet, length := in.Alphabet, int(in.Length)
+	if alphabet == "" {
+		alphabet = defaultCodeAlphabet
+	}
+	if length == 0 {
+		length = defaultCodeLength
+	}
+
+	// Check args valid, short code or small alphabet can be guessed
+	symbols := uniqueSymbols(alphabet)
+	if !(0 < in.Count && in.Count <= maxCodesCount && 6 <= length && length <= 32 && 2 <= len(symbols) && len(symbols) <= 64) {
+		return nil, e.ErrCampaignCodes
+	}
+
+	q := `INSERT INTO "Promos" ("Name", "Currency", "Amount", "Uses", "Creator", "ExpAt", "CampaignId")
+	      SELECT "Code", $2, $3, 1, $4, $5, $6 FROM unnest($1::TEXT[]) AS "Code"
+		  ON CONFLICT DO NOTHING
+		  RETURNING "Name"`
+	expAt := campaign.ExpAt.AsTime().Format("2006-01-02 15:04:05")
+
+	// Codes with conflicting names skipped, generate new codes instead of them
+	var codes = make([]string, 0, in.Count)
+	for attempt := 0; len(codes) < int(in.Count); attempt++ {
+		if attempt == maxGenerateAttempts {
+			return nil, e.ErrCampaignCodes
+		}
+
+		batch := make([]string, int(in.Count)-len(codes))
+		for i := range batch {
+			code, err := generateCode(symbols, length)
+			if err != nil {
+				return nil, err
+			}
+			batch[i] = code
+		}
+
+		rows, err := db.Query(ctx, q, batch, campaign.Currency, campaign.Amount, campaign.Creator, expAt, campaign.Id)
+		if err != nil {
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+
+		for rows.Next() {
+			var code string
+			if err := rows.Scan(&code); err != nil {
+				rows.Close()
+				return nil, errors.Join(e.ErrIncorrectData, err)
+			}
+
+			codes = append(codes, code)
+		}
+		rows.Close()
+
+		if err := rows.Err(); err != nil {
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	q = `UPDATE "Campaigns"
+	     SET "Codes" = "Codes"+$1
+		 WHERE "Id" = $2`
+
+	if _, err := db.Exec(ctx, q, len(codes), campaign.Id); err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+
+	return codes, nil
+}
+
+// Get page of codes of campaign from table promos
+func (r *Repository) GetCampaignCodes(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.ExportCodesIn) (*promos.CampaignCodes, error) {
+
+	var out = new(promos.CampaignCodes)
+
+	limit, err := page.Limit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := page.Decode(in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Codes of campaign created in one time, so cursor is only id
+	var afterId int64
+	if cursor != nil {
+		afterId = cursor.Id
+	}
+
+	q := `SELECT "Id", "Name", "Uses" = 0 FROM "Promos"
+	      WHERE "CampaignId" = $1 AND "Id" > $2 AND "DeletedAt" IS NULL
+		  ORDER BY "Id"
+		  LIMIT $3`
+
+	// One more row, for knowing next page exists or not
+	rows, err := db.Query(ctx, q, in.CampaignId, afterId, limit+1)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var code = new(promos.CampaignCode)
+		if err := rows.Scan(&id, &code.Code, &code.Used); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		out.Codes = append(out.Codes, code)
+		ids = append(ids, id)
+	}
+
+	if len(out.Codes) > int(limit) {
+		out.Codes = out.Codes[:limit]
+		out.NextPageToken = page.Encode(page.Cursor{Id: ids[limit-1]})
+	}
+
+	return out, nil
+}
+
+// Count redemption of code against campaign
+func (r *Repository) RedeemCampaignCode(
+	ctx context.Context,
+	db postgres.DB,
+	id int64) error {
+
+	q := `UPDATE "Campaigns"
+	      SET "Uses" = CASE WHEN "Uses" = -1 THEN -1 ELSE "Uses"-1 END, "Redeemed" = "Redeemed"+1
+		  WHERE "Id" = $1 AND "Uses" != 0`
+
+	tag, err := db.Exec(ctx, q, id)
+	if err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.ErrCampaignNotInStock
+	}
+
+	return nil
+}
+
+// Return symbols of alphabet without repeats
+func uniqueSymbols(alphabet string) []rune {
+	var seen = make(map[rune]bool)
+	var symbols []rune
+	for _, symbol := range alphabet {
+		if !seen[symbol] {
+			seen[symbol] = true
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols
+}
+
+// Generate random code from symbols, crypto/rand makes codes non-guessable
+func generateCode(symbols []rune, length int) (string, error) {
+	var code = make([]rune, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(symbols))))
+		if err != nil {
+			return "", err
+		}
+		code[i] = symbols[n.Int64()]
+	}
+
+	return string(code), nil
+}
+
+// Scan row of table campaigns, order of columns same as in table
+func scanCampaign(row pgx.Row) (*promos.Campaign, error) {
+	var expiredAt, createdAt time.Time
+	var out = new(promos.Campaign)
+
+	if err := row.Scan(
+		&out.Id,
+		&out.Name,
+		&out.Currency,
+		&out.Amount,
+		&out.Uses,
+		&out.Creator,
+		&expiredAt,
+		&createdAt,
+		&out.Codes,
+		&out.Redeemed); err != nil {
+		return nil, err
+	}
+
+	out.ExpAt, out.CreatedAt = timestamppb.New(expiredAt), timestamppb.New(createdAt)
+	return out, nil
+}
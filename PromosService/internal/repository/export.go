@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	e "errorspomka"
+	"postgres"
+	"protobuf/promos"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Get all promos from table promos, only promos of creator if it set. Deleted promos are exported with time of deleting.
+func (r *Repository) ExportPromos(
+	ctx context.Context,
+	db postgres.DB,
+	creator int64) ([]*promos.PromoCode, error) {
+
+	var out []*promos.PromoCode
+
+	q := `SELECT * FROM "Promos"
+	      WHERE $1::BIGINT = 0 OR "Creator" = $1
+		  ORDER BY "Id"`
+
+	rows, err := db.Query(ctx, q, creator)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		promo, err := scanPromo(rows)
+		if err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		out = append(out, promo)
+	}
+	rows.Close()
+
+	// Reward tables are exported with promos, so imported promo pays same rewards
+	if err := getPromoRewards(ctx, db, out...); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Get all activations of promos from table UserToPromo, deleted promos too, only activations of promos of creator if it set
+func (r *Repository) ExportActivations(
+	ctx context.Context,
+	db postgres.DB,
+	creator int64) ([]*promos.PromoActivation, error) {
+
+	var out []*promos.PromoActivation
+
+	q := `SELECT a."PromoId", a."UserId", a."ActivatedAt", COALESCE(a."Currency", 0), COALESCE(a."Amount", 0)
+	      FROM "UserToPromo" a
+		  JOIN "Promos" p ON p."Id" = a."PromoId"
+	      WHERE $1::BIGINT = 0 OR p."Creator" = $1
+		  ORDER BY a."PromoId", a."ActivatedAt", a."Id"`
+
+	rows, err := db.Query(ctx, q, creator)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var activatedAt time.Time
+		var activation = &promos.PromoActivation{Reward: new(promos.Reward)}
+		if err := rows.Scan(&activation.PromoId, &activation.UserId, &activatedAt, &activation.Reward.Currency, &activation.Reward.Amount); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		activation.ActivatedAt = timestamppb.New(activatedAt)
+		out = append(out, activation)
+	}
+
+	return out, nil
+}
@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	e "errorspomka"
+	"fmt"
 	"postgres"
 	"protobuf/promos"
 	"protobuf/users"
+	"slices"
+	"strings"
 	"time"
+	"utils/page"
 
 	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -24,64 +29,131 @@ func (r *Repository) CreatePromo(
 		return nil, e.ErrBadArgs
 	}
 
-	var expiredAt, createdAt time.Time // Scan() cannot convert sql timestamp to protobuf/types/known/timestamppb
-	var out = new(promos.PromoCode)
+	// Promo can't be started after expiration
+	var startsAt *string
+	if in.StartsAt != nil {
+		if !in.StartsAt.AsTime().Before(in.ExpAt.AsTime()) {
+			return nil, e.ErrStartsAt
+		}
+
+		t := in.StartsAt.AsTime().Format("2006-01-02 15:04:05")
+		startsAt = &t
+	}
+
+	// Audience of promo, empty conditions are NULL
+	var roles []int32
+	var createdAfter, createdBefore *string
+	var allowlist []int64
+	if a := in.Audience; a != nil {
+		for _, role := range a.Roles {
+			if role < users.Role_Blocked || role > users.Role_Creator {
+				return nil, e.ErrBadArgs
+			}
+			roles = append(roles, int32(role))
+		}
+		if a.CreatedAfter != nil && a.CreatedBefore != nil && !a.CreatedAfter.AsTime().Before(a.CreatedBefore.AsTime()) {
+			return nil, e.ErrBadArgs
+		}
+		if a.CreatedAfter != nil {
+			t := a.CreatedAfter.AsTime().Format("2006-01-02 15:04:05")
+			createdAfter = &t
+		}
+		if a.CreatedBefore != nil {
+			t := a.CreatedBefore.AsTime().Format("2006-01-02 15:04:05")
+			createdBefore = &t
+		}
+		allowlist = a.Users
+	}
+
+	// Activations by one user, one by default
+	userLimit := in.UserLimit
+	if userLimit == 0 {
+		userLimit = 1
+	}
+	if !(userLimit > 0 || userLimit == -1) || in.Cooldown.AsDuration() < 0 {
+		return nil, e.ErrBadArgs
+	}
+
+	if !validRewards(in.Rewards) {
+		return nil, e.ErrPromoRewards
+	}
 
-	q := `INSERT INTO "Promos" ("Name", "Currency", "Amount", "Uses", "Creator", "ExpAt")
-    VALUES ($1, $2, $3, $4, $5, $6) RETURNING "Id", "Name", "Currency", "Amount", "Uses", "Creator", "ExpAt", "CreatedAt"`
+	q := `INSERT INTO "Promos" ("Name", "Currency", "Amount", "Uses", "Creator", "ExpAt", "StartsAt",
+	      "AudienceRoles", "AudienceCreatedAfter", "AudienceCreatedBefore", "AudienceUsers", "UserLimit", "Cooldown")
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING *`
 	expAt := in.ExpAt.AsTime().Format("2006-01-02 15:04:05")
 
-	err := db.QueryRow(ctx, q,
+	out, err := scanPromo(db.QueryRow(ctx, q,
 		in.Name,
 		in.Currency,
 		in.Amount,
 		in.Uses,
 		in.Creator,
-		expAt).Scan(
-		&out.Id,
-		&out.Name,
-		&out.Currency,
-		&out.Amount,
-		&out.Uses,
-		&out.Creator,
-		&expiredAt,
-		&createdAt)
+		expAt,
+		startsAt,
+		roles,
+		createdAfter,
+		createdBefore,
+		allowlist,
+		userLimit,
+		int64(in.Cooldown.AsDuration().Seconds())))
 
 	if err != nil {
 		return nil, errors.Join(e.ErrExecQuery, err)
 	}
 
-	out.ExpAt, out.CreatedAt = timestamppb.New(expiredAt), timestamppb.New(createdAt)
+	// Add reward table of promo
+	if err := addPromoRewards(ctx, db, out.Id, in.Rewards); err != nil {
+		return nil, err
+	}
+	if len(in.Rewards.GetTiers()) > 0 || len(in.Rewards.GetOptions()) > 0 {
+		out.Rewards = in.Rewards
+	}
+
 	return out, nil
 }
 
-// Delete promo from table promos by ID
+// Mark promo in table promos as deleted by user, find promo by ID. History of activations kept.
 func (r *Repository) DeletePromoById(
 	ctx context.Context,
 	db postgres.DB,
-	in *promos.PromoId) error {
-	q := `DELETE FROM "Promos"
-	      WHERE "Id" = $1`
+	in *promos.PromoUserId) error {
+	q := `UPDATE "Promos"
+	      SET "DeletedAt" = $1, "DeletedBy" = $2
+		  WHERE "Id" = $3 AND "DeletedAt" IS NULL`
 
-	if _, err := db.Exec(ctx, q, in.Id); err != nil {
+	deletedAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	tag, err := db.Exec(ctx, q, deletedAt, in.UserId, in.PromoId)
+	if err != nil {
 		return errors.Join(e.ErrExecQuery, err)
 	}
 
+	if tag.RowsAffected() == 0 {
+		return e.ErrMissingPromoId
+	}
+
 	return nil
 }
 
-// Delete promo from table promos by Name
+// Mark promo in table promos as deleted by user, find promo by Name. History of activations kept.
 func (r *Repository) DeletePromoByName(
 	ctx context.Context,
 	db postgres.DB,
-	in *promos.PromoName) error {
-	q := `DELETE FROM "Promos"
-	      WHERE "Name" = $1`
+	in *promos.PromoUserName) error {
+	q := `UPDATE "Promos"
+	      SET "DeletedAt" = $1, "DeletedBy" = $2
+		  WHERE "Name" = $3 AND "DeletedAt" IS NULL`
 
-	if _, err := db.Exec(ctx, q, in.Name); err != nil {
+	deletedAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	tag, err := db.Exec(ctx, q, deletedAt, in.UserId, in.Name)
+	if err != nil {
 		return errors.Join(e.ErrExecQuery, err)
 	}
 
+	if tag.RowsAffected() == 0 {
+		return e.ErrMissingPromoName
+	}
+
 	return nil
 }
 
@@ -91,23 +163,10 @@ func (r *Repository) GetPromoById(
 	db postgres.DB,
 	in *promos.PromoId) (*promos.PromoCode, error) {
 
-	var expiredAt, createdAt time.Time
-	var out = new(promos.PromoCode)
-
 	q := `SELECT * FROM "Promos"
 	      WHERE "Id" = $1`
-	row := db.QueryRow(ctx, q, in.Id)
-
-	err := row.Scan(
-		&out.Id,
-		&out.Name,
-		&out.Currency,
-		&out.Amount,
-		&out.Uses,
-		&out.Creator,
-		&expiredAt,
-		&createdAt)
 
+	out, err := scanPromo(db.QueryRow(ctx, q, in.Id))
 	if err != nil {
 		switch err {
 		case pgx.ErrNoRows:
@@ -117,7 +176,10 @@ func (r *Repository) GetPromoById(
 		}
 	}
 
-	out.ExpAt, out.CreatedAt = timestamppb.New(expiredAt), timestamppb.New(createdAt)
+	if err := getPromoRewards(ctx, db, out); err != nil {
+		return nil, err
+	}
+
 	return out, nil
 }
 
@@ -127,23 +189,10 @@ func (r *Repository) GetPromoByName(
 	db postgres.DB,
 	in *promos.PromoName) (*promos.PromoCode, error) {
 
-	var expiredAt, createdAt time.Time
-	var out = new(promos.PromoCode)
-
 	q := `SELECT * FROM "Promos"
-	      WHERE "Name" = $1`
-	row := db.QueryRow(ctx, q, in.Name)
-
-	err := row.Scan(
-		&out.Id,
-		&out.Name,
-		&out.Currency,
-		&out.Amount,
-		&out.Uses,
-		&out.Creator,
-		&expiredAt,
-		&createdAt)
+	      WHERE "Name" = $1 AND "DeletedAt" IS NULL`
 
+	out, err := scanPromo(db.QueryRow(ctx, q, in.Name))
 	if err != nil {
 		switch err {
 		case pgx.ErrNoRows:
@@ -153,7 +202,270 @@ func (r *Repository) GetPromoByName(
 		}
 	}
 
-	out.ExpAt, out.CreatedAt = timestamppb.New(expiredAt), timestamppb.New(createdAt)
+	if err := getPromoRewards(ctx, db, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Get promo from table promos by Name, case and extra whitespaces ignored
+func (r *Repository) GetPromoByNormalizedName(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.PromoName) (*promos.PromoCode, error) {
+
+	q := `SELECT * FROM "Promos"
+	      WHERE lower(btrim(regexp_replace("Name", '\s+', ' ', 'g'))) = lower(btrim(regexp_replace($1, '\s+', ' ', 'g')))
+		  AND "DeletedAt" IS NULL`
+
+	out, err := scanPromo(db.QueryRow(ctx, q, in.Name))
+	if err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, errors.Join(e.ErrMissingPromoName, err)
+		default:
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	if err := getPromoRewards(ctx, db, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Get page of promos from table promos, filtered by state, creator, currency and prefix of name
+func (r *Repository) ListPromos(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.PromoFilter) (*promos.AllPromos, error) {
+
+	var allPromos = new(promos.AllPromos)
+
+	limit, err := page.Limit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := page.Decode(in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *string
+	var afterId int64
+	if cursor != nil {
+		t := cursor.Time.Format("2006-01-02 15:04:05.999999")
+		after, afterId = &t, cursor.Id
+	}
+
+	// Column of ordering is column of cursor
+	column, cmp, order := "CreatedAt", ">", "ASC"
+	if in.Order == promos.PromoOrder_ByExpAt {
+		column = "ExpAt"
+	}
+	if in.Descending {
+		cmp, order = "<", "DESC"
+	}
+
+	q := fmt.Sprintf(`SELECT * FROM "Promos"
+	      WHERE ($1::SMALLINT = 0
+		      OR ($1 = 1 AND "IsActive" AND "ExpAt" > $8 AND "Uses" != 0 AND ("StartsAt" IS NULL OR "StartsAt" <= $8))
+		      OR ($1 = 2 AND "ExpAt" <= $8)
+		      OR ($1 = 3 AND "Uses" = 0)
+		      OR ($1 = 4 AND "StartsAt" > $8)
+		      OR ($1 = 5 AND NOT "IsActive"))
+		  AND ($2::BIGINT IS NULL OR "Creator" = $2)
+		  AND ($3::SMALLINT IS NULL OR "Currency" = $3)
+		  AND "Name" LIKE $4::TEXT || '%%'
+		  AND "DeletedAt" IS NULL
+		  AND "CampaignId" IS NULL
+		  AND "ReferrerId" IS NULL
+		  AND ($5::TIMESTAMP IS NULL OR ("%[1]s", "Id") %[2]s ($5::TIMESTAMP, $6))
+		  ORDER BY "%[1]s" %[3]s, "Id" %[3]s
+		  LIMIT $7`, column, cmp, order)
+
+	// One more row, for knowing next page exists or not
+	now := time.Now().UTC()
+	rows, err := db.Query(ctx, q,
+		in.State,
+		in.Creator,
+		in.Currency,
+		likeEscaper.Replace(in.NamePrefix),
+		after,
+		afterId,
+		limit+1,
+		now.Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		promo, err := scanPromo(rows)
+		if err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		info := &promos.PromoInfo{PromoCode: promo, UsesLeft: promo.Uses, ExpiresIn: durationpb.New(0)}
+		if expAt := promo.ExpAt.AsTime(); expAt.After(now) {
+			info.ExpiresIn = durationpb.New(expAt.Sub(now))
+		}
+
+		allPromos.Promos = append(allPromos.Promos, info)
+	}
+	rows.Close()
+
+	if len(allPromos.Promos) > int(limit) {
+		allPromos.Promos = allPromos.Promos[:limit]
+		last := allPromos.Promos[limit-1].PromoCode
+		t := last.CreatedAt.AsTime()
+		if in.Order == promos.PromoOrder_ByExpAt {
+			t = last.ExpAt.AsTime()
+		}
+		allPromos.NextPageToken = page.Encode(page.Cursor{Time: t, Id: last.Id})
+	}
+
+	var promoCodes = make([]*promos.PromoCode, 0, len(allPromos.Promos))
+	for _, info := range allPromos.Promos {
+		promoCodes = append(promoCodes, info.PromoCode)
+	}
+	if err := getPromoRewards(ctx, db, promoCodes...); err != nil {
+		return nil, err
+	}
+
+	return allPromos, nil
+}
+
+// Get statistics of activations of promo from table UserToPromo
+func (r *Repository) GetPromoStats(
+	ctx context.Context,
+	db postgres.DB,
+	promo *promos.PromoCode,
+	bucket promos.StatsBucket) (*promos.PromoStats, error) {
+
+	var out = &promos.PromoStats{PromoId: promo.Id}
+
+	q := `SELECT COUNT(*), COUNT(DISTINCT "UserId")
+	      FROM "UserToPromo"
+	      WHERE "PromoId" = $1`
+
+	if err := db.QueryRow(ctx, q, promo.Id).Scan(&out.Activations, &out.UniqueUsers); err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+
+	// Rewards of activations can be in other currencies than currency of promo, paid out summed by every currency
+	q = `SELECT "Currency", SUM("Amount") FROM "UserToPromo"
+	     WHERE "PromoId" = $1 AND "Currency" IS NOT NULL
+		 GROUP BY "Currency"
+		 ORDER BY "Currency"`
+
+	paidRows, err := db.Query(ctx, q, promo.Id)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer paidRows.Close()
+
+	for paidRows.Next() {
+		var paid = new(promos.Reward)
+		if err := paidRows.Scan(&paid.Currency, &paid.Amount); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		out.PaidOut = append(out.PaidOut, paid)
+	}
+
+	// Activations by hours or days
+	trunc := "day"
+	if bucket == promos.StatsBucket_Hour {
+		trunc = "hour"
+	}
+
+	q = `SELECT date_trunc($2, "ActivatedAt") AS "Start", COUNT(*) FROM "UserToPromo"
+	     WHERE "PromoId" = $1
+		 GROUP BY "Start"
+		 ORDER BY "Start"`
+
+	rows, err := db.Query(ctx, q, promo.Id, trunc)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var start time.Time
+		var bucket = new(promos.ActivationsBucket)
+		if err := rows.Scan(&start, &bucket.Activations); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		bucket.Start = timestamppb.New(start)
+		out.Buckets = append(out.Buckets, bucket)
+	}
+
+	return out, nil
+}
+
+// Get page of activations of promo from table UserToPromo, newest first
+func (r *Repository) GetPromoActivations(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.PromoActivationsIn) (*promos.PromoActivations, error) {
+
+	var out = new(promos.PromoActivations)
+
+	limit, err := page.Limit(in.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := page.Decode(in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *string
+	var afterId int64
+	if cursor != nil {
+		t := cursor.Time.Format("2006-01-02 15:04:05.999999")
+		after, afterId = &t, cursor.Id
+	}
+
+	q := `SELECT "Id", "UserId", "ActivatedAt", COALESCE("Currency", 0), COALESCE("Amount", 0) FROM "UserToPromo"
+	      WHERE "PromoId" = $1
+		  AND ($2::TIMESTAMP IS NULL OR ("ActivatedAt", "Id") < ($2::TIMESTAMP, $3))
+		  ORDER BY "ActivatedAt" DESC, "Id" DESC
+		  LIMIT $4`
+
+	// One more row, for knowing next page exists or not
+	rows, err := db.Query(ctx, q, in.PromoId, after, afterId, limit+1)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var activatedAt time.Time
+		var activation = &promos.PromoActivation{Reward: new(promos.Reward)}
+		if err := rows.Scan(&id, &activation.UserId, &activatedAt, &activation.Reward.Currency, &activation.Reward.Amount); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		activation.ActivatedAt = timestamppb.New(activatedAt)
+		out.Activations = append(out.Activations, activation)
+		ids = append(ids, id)
+	}
+
+	if len(out.Activations) > int(limit) {
+		out.Activations = out.Activations[:limit]
+		last := out.Activations[limit-1]
+		out.NextPageToken = page.Encode(page.Cursor{Time: last.ActivatedAt.AsTime(), Id: ids[limit-1]})
+	}
+
 	return out, nil
 }
 
@@ -166,14 +478,21 @@ func (r *Repository) DecrementPromoUses(
 		return nil
 	}
 
+	// Promo with infinity uses updated too, update locks promo till end of transaction
 	q := `UPDATE "Promos"
-	      SET "Uses" = "Uses"-1
-		  WHERE "Id" = $1`
+	      SET "Uses" = CASE WHEN "Uses" = -1 THEN -1 ELSE "Uses"-1 END
+		  WHERE "Id" = $1 AND "Uses" != 0`
 
-	if _, err := db.Exec(ctx, q, in.Id); err != nil {
+	tag, err := db.Exec(ctx, q, in.Id)
+	if err != nil {
 		return errors.Join(e.ErrExecQuery, err)
 	}
 
+	// Other user took last use after promo was read
+	if tag.RowsAffected() == 0 {
+		return e.ErrPromoNotInStock
+	}
+
 	return nil
 }
 
@@ -181,12 +500,13 @@ func (r *Repository) DecrementPromoUses(
 func (r *Repository) AddActivatePromoToHistory(
 	ctx context.Context,
 	db postgres.DB,
-	in *promos.PromoUserId) (err error) {
-	q := `INSERT INTO "UserToPromo" ("UserId", "PromoId", "ActivatedAt")
-	      VALUES ($1, $2, $3)`
+	in *promos.PromoUserId,
+	reward *promos.Reward) (err error) {
+	q := `INSERT INTO "UserToPromo" ("UserId", "PromoId", "ActivatedAt", "Currency", "Amount")
+	      VALUES ($1, $2, $3, $4, $5)`
 
-	actAt := time.Now().Format("2006-01-02 15:04:05")
-	if _, err := db.Exec(ctx, q, in.UserId, in.PromoId, actAt); err != nil {
+	actAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	if _, err := db.Exec(ctx, q, in.UserId, in.PromoId, actAt, reward.Currency, reward.Amount); err != nil {
 		return errors.Join(e.ErrExecQuery, err)
 	}
 	return nil
@@ -211,27 +531,49 @@ func (r *Repository) DeleteActivatePromoFromHistory(
 func (r *Repository) PromoIsAlreadyActivated(
 	ctx context.Context,
 	db postgres.DB,
-	in *promos.PromoUserId) (b bool, err error) {
+	promo *promos.PromoCode,
+	userId int64) (b bool, err error) {
 
-	var activated = new(bool)
+	var count int64
+	var lastActivatedAt *time.Time
 
-	q := `SELECT EXISTS(
-	      SELECT * FROM "UserToPromo"
-		  WHERE "UserId" = $1 AND "PromoId" = $2
-		  )`
+	q := `SELECT count(*), max("ActivatedAt") FROM "UserToPromo"
+	      WHERE "UserId" = $1 AND "PromoId" = $2`
 
-	row := db.QueryRow(ctx, q, in.UserId, in.PromoId)
-	if err := row.Scan(&activated); err != nil {
+	row := db.QueryRow(ctx, q, userId, promo.Id)
+	if err := row.Scan(&count, &lastActivatedAt); err != nil {
 		return false, errors.Join(e.ErrExecQuery, err)
 	}
 
-	if *activated {
-		return true, errors.Join(e.ErrPromoAlreadyActivated, err)
+	if count == 0 {
+		return false, nil
+	}
+
+	// Activations by user are over
+	if promo.UserLimit != -1 && count >= int64(promo.UserLimit) {
+		if promo.UserLimit == 1 {
+			return true, e.ErrPromoAlreadyActivated
+		}
+		return true, fmt.Errorf("%w: limit is %d", e.ErrPromoUserLimit, promo.UserLimit)
+	}
+
+	// Cooldown after last activation, failure says when next activation is allowed
+	if next := lastActivatedAt.Add(promo.Cooldown.AsDuration()); time.Now().Before(next) {
+		return true, fmt.Errorf("%w: next activation at %s", e.ErrPromoCooldown, next.Format(time.RFC3339))
 	}
 
 	return false, nil
 }
 
+// Check promo is deleted or not
+func (r *Repository) PromoIsNotDeleted(in *promos.PromoCode) (b bool, err error) {
+	if in.DeletedAt != nil {
+		return false, e.ErrPromoDeleted
+	}
+
+	return true, nil
+}
+
 // Check promo is expired or not
 func (r *Repository) PromoIsExpired(in *promos.PromoCode) (b bool, err error) {
 	if float64(time.Now().Unix()) > float64(in.ExpAt.AsTime().Unix()) {
@@ -241,6 +583,75 @@ func (r *Repository) PromoIsExpired(in *promos.PromoCode) (b bool, err error) {
 	return true, nil
 }
 
+// Check promo is paused or not
+func (r *Repository) PromoIsActive(in *promos.PromoCode) (b bool, err error) {
+	if !in.IsActive {
+		return false, e.ErrPromoPaused
+	}
+
+	return true, nil
+}
+
+// Check promo is started or not
+func (r *Repository) PromoIsStarted(in *promos.PromoCode) (b bool, err error) {
+	if in.StartsAt != nil && time.Now().Before(in.StartsAt.AsTime()) {
+		return false, fmt.Errorf("%w: starts at %s", e.ErrPromoNotStarted, in.StartsAt.AsTime().Format(time.RFC3339))
+	}
+
+	return true, nil
+}
+
+// Check user is in audience of promo or not. Error says which condition user doesn't match.
+func (r *Repository) UserIsInAudience(in *promos.PromoCode, user *users.User) (b bool, err error) {
+	a := in.Audience
+	if a == nil {
+		return true, nil
+	}
+
+	if len(a.Roles) > 0 && !slices.Contains(a.Roles, user.GetRole()) {
+		return false, e.ErrPromoAudienceRole
+	}
+
+	createdAt := user.GetCreatedAt().AsTime()
+	if a.CreatedAfter != nil && !createdAt.After(a.CreatedAfter.AsTime()) {
+		return false, e.ErrPromoAudienceDate
+	}
+	if a.CreatedBefore != nil && !createdAt.Before(a.CreatedBefore.AsTime()) {
+		return false, e.ErrPromoAudienceDate
+	}
+
+	if len(a.Users) > 0 && !slices.Contains(a.Users, user.GetId()) {
+		return false, e.ErrPromoAudienceUser
+	}
+
+	return true, nil
+}
+
+// Check new time of promo is valid or not: start of promo after changing must be before expiration
+func (r *Repository) PromoTimeIsValid(promo *promos.PromoCode, in *promos.AddTimeIn) (b bool, err error) {
+	if in.ClearStartsAt && in.StartsAt != nil {
+		return false, e.ErrClearStartsAt
+	}
+
+	expAt, startsAt := promo.ExpAt, promo.StartsAt
+	if in.ExpAt != nil {
+		expAt = in.ExpAt
+	}
+	if in.StartsAt != nil {
+		startsAt = in.StartsAt
+	}
+	if in.ClearStartsAt {
+		startsAt = nil
+	}
+
+	// Time stored with seconds
+	if startsAt != nil && !startsAt.AsTime().Truncate(time.Second).Before(expAt.AsTime().Truncate(time.Second)) {
+		return false, e.ErrStartsAt
+	}
+
+	return true, nil
+}
+
 // Check promo in stock or not
 func (r *Repository) PromoIsNotInStock(in *promos.PromoCode) (b bool, err error) {
 	if in.Uses == 0 {
@@ -260,15 +671,43 @@ func (r *Repository) CreatorIsOwner(ctx context.Context, user *users.User) (b bo
 	return false, e.ErrCreatorIsNotOwner
 }
 
-// Add time for promo
+// Pause or resume promo
+func (r *Repository) SetPromoActive(ctx context.Context, db postgres.DB, in *promos.PromoId, active bool) (err error) {
+
+	q := `UPDATE "Promos"
+	      SET "IsActive" = $1
+		  WHERE "Id" = $2 AND "DeletedAt" IS NULL`
+
+	tag, err := db.Exec(ctx, q, active, in.Id)
+	if err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return e.ErrMissingPromoId
+	}
+
+	return nil
+}
+
+// Add time for promo, move expiration and/or start of promo
 func (r *Repository) AddTime(ctx context.Context, db postgres.DB, in *promos.AddTimeIn) (err error) {
 
 	q := `UPDATE "Promos"
-	      SET "ExpAt" = $1
-		  WHERE "Id" = $2`
-	expAt := in.ExpAt.AsTime().Format("2006-01-02 15:04:05")
+	      SET "ExpAt" = COALESCE($1, "ExpAt"), "StartsAt" = CASE WHEN $4 THEN NULL ELSE COALESCE($3, "StartsAt") END
+		  WHERE "Id" = $2 AND "DeletedAt" IS NULL`
+
+	var expAt, startsAt *string
+	if in.ExpAt != nil {
+		t := in.ExpAt.AsTime().Format("2006-01-02 15:04:05")
+		expAt = &t
+	}
+	if in.StartsAt != nil {
+		t := in.StartsAt.AsTime().Format("2006-01-02 15:04:05")
+		startsAt = &t
+	}
 
-	if _, err := db.Exec(ctx, q, expAt, in.PromoId); err != nil {
+	if _, err := db.Exec(ctx, q, expAt, in.PromoId, startsAt, in.ClearStartsAt); err != nil {
 		return errors.Join(e.ErrExecQuery, err)
 	}
 
@@ -280,7 +719,7 @@ func (r *Repository) AddUses(ctx context.Context, db postgres.DB, in *promos.Add
 
 	q := `UPDATE "Promos"
 	      SET "Uses" = "Uses"+$1
-		  WHERE "Id" = $2`
+		  WHERE "Id" = $2 AND "DeletedAt" IS NULL`
 
 	if _, err := db.Exec(ctx, q, in.Uses, in.PromoId); err != nil {
 		return errors.Join(e.ErrExecQuery, err)
@@ -288,3 +727,81 @@ func (r *Repository) AddUses(ctx context.Context, db postgres.DB, in *promos.Add
 
 	return nil
 }
+
+// Escape special symbols of LIKE, so prefix of name matched as is
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Scan row of table promos, order of columns same as in table
+func scanPromo(row pgx.Row) (*promos.PromoCode, error) {
+	var expiredAt, createdAt time.Time // Scan() cannot convert sql timestamp to protobuf/types/known/timestamppb
+	var startsAt *time.Time
+	var campaignId *int64
+	var roles []int32
+	var createdAfter, createdBefore *time.Time
+	var allowlist []int64
+	var referrer *int64
+	var cooldown int64
+	var deletedAt *time.Time
+	var deletedBy *int64
+	var out = new(promos.PromoCode)
+
+	if err := row.Scan(
+		&out.Id,
+		&out.Name,
+		&out.Currency,
+		&out.Amount,
+		&out.Uses,
+		&out.Creator,
+		&expiredAt,
+		&createdAt,
+		&startsAt,
+		&out.IsActive,
+		&campaignId,
+		&roles,
+		&createdAfter,
+		&createdBefore,
+		&allowlist,
+		&referrer,
+		&out.ReferrerAmount,
+		&out.UserLimit,
+		&cooldown,
+		&deletedAt,
+		&deletedBy); err != nil {
+		return nil, err
+	}
+
+	out.ExpAt, out.CreatedAt = timestamppb.New(expiredAt), timestamppb.New(createdAt)
+	if startsAt != nil {
+		out.StartsAt = timestamppb.New(*startsAt)
+	}
+	if campaignId != nil {
+		out.CampaignId = *campaignId
+	}
+	if referrer != nil {
+		out.Referrer = *referrer
+	}
+	if cooldown > 0 {
+		out.Cooldown = durationpb.New(time.Duration(cooldown) * time.Second)
+	}
+	if deletedAt != nil {
+		out.DeletedAt = timestamppb.New(*deletedAt)
+	}
+	if deletedBy != nil {
+		out.DeletedBy = *deletedBy
+	}
+
+	// Audience set, if promo has any condition
+	if len(roles) > 0 || createdAfter != nil || createdBefore != nil || len(allowlist) > 0 {
+		out.Audience = &promos.Audience{Users: allowlist}
+		for _, role := range roles {
+			out.Audience.Roles = append(out.Audience.Roles, users.Role(role))
+		}
+		if createdAfter != nil {
+			out.Audience.CreatedAfter = timestamppb.New(*createdAfter)
+		}
+		if createdBefore != nil {
+			out.Audience.CreatedBefore = timestamppb.New(*createdBefore)
+		}
+	}
+	return out, nil
+}
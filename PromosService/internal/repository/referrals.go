@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	e "errorspomka"
+	"postgres"
+	"protobuf/common"
+	"protobuf/promos"
+	"protobuf/users"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	referralCodePrefix = "REF-"
+	referralCodeLength = 10
+)
+
+// Referral code never expires
+var referralExpAt = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+// Get referral code of user from table promos
+func (r *Repository) GetReferralCode(
+	ctx context.Context,
+	db postgres.DB,
+	user *users.Id) (*promos.PromoCode, error) {
+
+	q := `SELECT * FROM "Promos"
+	      WHERE "ReferrerId" = $1 AND "DeletedAt" IS NULL`
+
+	out, err := scanPromo(db.QueryRow(ctx, q, user.Id))
+	if err != nil {
+		switch err {
+		case pgx.ErrNoRows:
+			return nil, errors.Join(e.ErrMissingPromoId, err)
+		default:
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	return out, nil
+}
+
+// Insert referral code of user to table promos, name of code generated.
+// New user gets amount, owner of code gets referrer amount.
+func (r *Repository) CreateReferralCode(
+	ctx context.Context,
+	db postgres.DB,
+	user *users.Id,
+	currency common.Currency,
+	amount, referrerAmount int64) (*promos.PromoCode, error) {
+
+	q := `INSERT INTO "Promos" ("Name", "Currency", "Amount", "Uses", "Creator", "ExpAt", "ReferrerId", "ReferrerAmount")
+	      VALUES ($1, $2, $3, -1, $4, $5, $4, $6)
+		  ON CONFLICT DO NOTHING
+		  RETURNING *`
+	expAt := referralExpAt.Format("2006-01-02 15:04:05")
+
+	// Code with conflicting name skipped, generate new code instead of it
+	for range maxGenerateAttempts {
+		code, err := generateCode([]rune(defaultCodeAlphabet), referralCodeLength)
+		if err != nil {
+			return nil, err
+		}
+
+		out, err := scanPromo(db.QueryRow(ctx, q, referralCodePrefix+code, currency, amount, user.Id, expAt, referrerAmount))
+		if err == pgx.ErrNoRows {
+
+			// Code of user created by parallel request, return it
+			if out, err := r.GetReferralCode(ctx, db, user); err == nil {
+				return out, nil
+			} else if !errors.Is(err, e.ErrMissingPromoId) {
+				return nil, err
+			}
+
+			continue
+		}
+		if err != nil {
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+
+		return out, nil
+	}
+
+	return nil, e.ErrUniquePromo
+}
+
+// Check user can join with referral code or not: user isn't owner of code, owner of code didn't join with code of user,
+// user didn't join before and user is new, created after code and didn't activate promos
+func (r *Repository) UserCanBeReferred(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.PromoCode,
+	user *users.User) (b bool, err error) {
+
+	if in.Referrer == user.GetId() {
+		return false, e.ErrReferralOwnCode
+	}
+
+	var mutual, referred, activated bool
+
+	q := `SELECT
+	      EXISTS(SELECT * FROM "Referrals" WHERE "UserId" = $2 AND "ReferrerId" = $1),
+	      EXISTS(SELECT * FROM "Referrals" WHERE "UserId" = $1),
+	      EXISTS(SELECT * FROM "UserToPromo" WHERE "UserId" = $1)`
+
+	if err := db.QueryRow(ctx, q, user.GetId(), in.Referrer).Scan(&mutual, &referred, &activated); err != nil {
+		return false, errors.Join(e.ErrExecQuery, err)
+	}
+
+	if mutual {
+		return false, e.ErrReferralMutual
+	}
+
+	if referred {
+		return false, e.ErrAlreadyReferred
+	}
+
+	// Time of creation stored with seconds, so user created in same second as code is new
+	if activated || user.GetCreatedAt().AsTime().Before(in.CreatedAt.AsTime().Truncate(time.Second)) {
+		return false, e.ErrReferralNotNewUser
+	}
+
+	return true, nil
+}
+
+// Insert user, who joined with referral code, to table referrals
+func (r *Repository) AddReferral(
+	ctx context.Context,
+	db postgres.DB,
+	in *promos.PromoCode,
+	userId int64) error {
+
+	q := `INSERT INTO "Referrals" ("ReferrerId", "UserId", "PromoId", "JoinedAt")
+	      VALUES ($1, $2, $3, $4)`
+
+	joinedAt := time.Now().UTC().Format("2006-01-02 15:04:05")
+	if _, err := db.Exec(ctx, q, in.Referrer, userId, in.Id, joinedAt); err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+
+	return nil
+}
+
+// Get users, who joined with referral code of user, from table referrals
+func (r *Repository) GetUserReferrals(
+	ctx context.Context,
+	db postgres.DB,
+	user *users.Id) (*promos.Referrals, error) {
+
+	var out = new(promos.Referrals)
+
+	q := `SELECT "UserId", "JoinedAt" FROM "Referrals"
+	      WHERE "ReferrerId" = $1
+		  ORDER BY "JoinedAt"`
+
+	rows, err := db.Query(ctx, q, user.Id)
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var joinedAt time.Time
+		var referral = new(promos.Referral)
+		if err := rows.Scan(&referral.UserId, &joinedAt); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		referral.JoinedAt = timestamppb.New(joinedAt)
+		out.Referrals = append(out.Referrals, referral)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	e "errorspomka"
+	"math/big"
+	"postgres"
+	"protobuf/promos"
+)
+
+// Check reward table valid: tiers ordered by upTo, options have weight
+func validRewards(in *promos.RewardTable) bool {
+	var upTo int64
+	for _, tier := range in.GetTiers() {
+		if !(tier.UpTo > upTo && 0 <= tier.Currency && tier.Currency <= 2 && tier.Amount >= 0) {
+			return false
+		}
+		upTo = tier.UpTo
+	}
+
+	for _, option := range in.GetOptions() {
+		if !(option.Weight > 0 && 0 <= option.Currency && option.Currency <= 2 && option.Amount >= 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Insert reward table of promo to table promo rewards
+func addPromoRewards(ctx context.Context, db postgres.DB, promoId int64, in *promos.RewardTable) error {
+	q := `INSERT INTO "PromoRewards" ("PromoId", "UpTo", "Weight", "Currency", "Amount")
+	      VALUES ($1, $2, $3, $4, $5)`
+
+	for _, tier := range in.GetTiers() {
+		if _, err := db.Exec(ctx, q, promoId, tier.UpTo, nil, tier.Currency, tier.Amount); err != nil {
+			return errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	for _, option := range in.GetOptions() {
+		if _, err := db.Exec(ctx, q, promoId, nil, option.Weight, option.Currency, option.Amount); err != nil {
+			return errors.Join(e.ErrExecQuery, err)
+		}
+	}
+
+	return nil
+}
+
+// Add reward tables to promos from table promo rewards
+func getPromoRewards(ctx context.Context, db postgres.DB, in ...*promos.PromoCode) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	var byId = make(map[int64]*promos.PromoCode, len(in))
+	var ids = make([]int64, 0, len(in))
+	for _, promo := range in {
+		byId[promo.Id] = promo
+		ids = append(ids, promo.Id)
+	}
+
+	q := `SELECT "PromoId", "UpTo", "Weight", "Currency", "Amount" FROM "PromoRewards"
+	      WHERE "PromoId" = ANY($1)
+		  ORDER BY "UpTo", "Id"`
+
+	rows, err := db.Query(ctx, q, ids)
+	if err != nil {
+		return errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		var upTo *int64
+		var weight *int32
+		var reward = new(promos.Reward)
+		if err := rows.Scan(&id, &upTo, &weight, &reward.Currency, &reward.Amount); err != nil {
+			return errors.Join(e.ErrIncorrectData, err)
+		}
+
+		promo := byId[id]
+		if promo.Rewards == nil {
+			promo.Rewards = new(promos.RewardTable)
+		}
+
+		if upTo != nil {
+			promo.Rewards.Tiers = append(promo.Rewards.Tiers, &promos.RewardTier{UpTo: *upTo, Currency: reward.Currency, Amount: reward.Amount})
+		} else {
+			promo.Rewards.Options = append(promo.Rewards.Options, &promos.RewardOption{Weight: *weight, Currency: reward.Currency, Amount: reward.Amount})
+		}
+	}
+
+	return nil
+}
+
+// Choose reward of next activation of promo by its reward table.
+// Call it after decrement of uses, update of promo locks it and number of activation can't be taken twice.
+func (r *Repository) RollReward(
+	ctx context.Context,
+	db postgres.DB,
+	promo *promos.PromoCode) (*promos.Reward, error) {
+
+	// First activators get reward of tier
+	if tiers := promo.Rewards.GetTiers(); len(tiers) > 0 {
+		var activations int64
+
+		q := `SELECT COUNT(*) FROM "UserToPromo"
+		      WHERE "PromoId" = $1`
+
+		if err := db.QueryRow(ctx, q, promo.Id).Scan(&activations); err != nil {
+			return nil, errors.Join(e.ErrExecQuery, err)
+		}
+
+		for _, tier := range tiers {
+			if activations+1 <= tier.UpTo {
+				return &promos.Reward{Currency: tier.Currency, Amount: tier.Amount}, nil
+			}
+		}
+	}
+
+	// Weighted random draw among options, crypto/rand makes outcome non-guessable
+	if options := promo.Rewards.GetOptions(); len(options) > 0 {
+		var total int64
+		for _, option := range options {
+			total += int64(option.Weight)
+		}
+
+		n, err := rand.Int(rand.Reader, big.NewInt(total))
+		if err != nil {
+			return nil, err
+		}
+
+		roll := n.Int64()
+		for _, option := range options {
+			if roll < int64(option.Weight) {
+				return &promos.Reward{Currency: option.Currency, Amount: option.Amount}, nil
+			}
+			roll -= int64(option.Weight)
+		}
+	}
+
+	return &promos.Reward{Currency: promo.Currency, Amount: promo.Amount}, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"protobuf/common"
+	"protobuf/promos"
+	"protobuf/users"
+	"utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *ServicePromos) CreateCampaign(ctx context.Context, in *promos.CreateCampaignIn) (campaignFailure *promos.CampaignFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	campaignFailure = new(promos.CampaignFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about creator
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.Creator})
+		if err != nil {
+			return err
+		}
+
+		// Check creator is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Creating campaign
+		campaignFailure.Campaign, err = s.repo.CreateCampaign(ctx, tx, in)
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		// Send transaction to service users
+		if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+			Sender: &users.UserTransaction{UserId: in.Creator},
+			Type:   common.TransactionType_CreatePromoCode,
+		}); err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.CampaignFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return campaignFailure, nil
+}
+
+func (s *ServicePromos) GenerateCodes(ctx context.Context, in *promos.GenerateCodesIn) (codesFailure *promos.GeneratedCodesFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	codesFailure = &promos.GeneratedCodesFailure{Codes: new(promos.GeneratedCodes)}
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about creator
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.Creator})
+		if err != nil {
+			return err
+		}
+
+		// Check creator is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Query to db for get campaign
+		campaign, err := s.repo.GetCampaignById(ctx, tx, in.CampaignId)
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		// Generating codes, every code is single-use promo with reward of campaign
+		codesFailure.Codes.Codes, err = s.repo.GenerateCampaignCodes(ctx, tx, campaign, in)
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.GeneratedCodesFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return codesFailure, nil
+}
+
+func (s *ServicePromos) ExportCodes(ctx context.Context, in *promos.ExportCodesIn) (codesFailure *promos.CampaignCodesFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	codesFailure = new(promos.CampaignCodesFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about caller
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.Creator})
+		if err != nil {
+			return err
+		}
+
+		// Only owner can see unredeemed codes
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Get page of codes
+		codesFailure.Codes, err = s.repo.GetCampaignCodes(ctx, tx, in)
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.CampaignCodesFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return codesFailure, nil
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"promos/internal/files"
+	"protobuf/common"
+	"protobuf/promos"
+	"protobuf/users"
+	"slices"
+	"utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *ServicePromos) Import(ctx context.Context, in *promos.ImportIn) (importFailure *promos.ImportResultFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	importFailure = &promos.ImportResultFailure{Result: new(promos.ImportResult)}
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about creator
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.UserId})
+		if err != nil {
+			return err
+		}
+
+		// Check creator is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Parse file, rows which can't be parsed reported
+		rows, rowErrors, err := files.ParsePromos(in.Format, in.Data)
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+		result := importFailure.Result
+		result.Errors = rowErrors
+
+		// Creating promos, rows with errors skipped
+		for _, row := range rows {
+			row.Promo.Creator = in.UserId
+
+			promo, err := s.importPromo(ctx, tx, row.Promo)
+			if err != nil {
+				result.Errors = append(result.Errors, &promos.ImportRowError{Row: row.Number, Error: err.Error()})
+				continue
+			}
+
+			result.Promos = append(result.Promos, promo)
+		}
+
+		slices.SortFunc(result.Errors, func(a, b *promos.ImportRowError) int {
+			return cmp.Compare(a.Row, b.Row)
+		})
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.ImportResultFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return importFailure, nil
+}
+
+// Create promo of imported row in savepoint, so error of row doesn't break transaction
+func (s *ServicePromos) importPromo(ctx context.Context, tx pgx.Tx, in *promos.CreatePromo) (*promos.PromoCode, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer savepoint.Rollback(ctx)
+
+	// Creating promo, same checks as in Create
+	promo, err := s.repo.CreatePromo(ctx, savepoint, in)
+	if err != nil {
+		return nil, err
+	}
+
+	// Send transaction to service users
+	if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+		Sender: &users.UserTransaction{UserId: in.Creator},
+		Type:   common.TransactionType_CreatePromoCode,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := savepoint.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return promo, nil
+}
+
+func (s *ServicePromos) Export(ctx context.Context, in *promos.ExportIn) (exportFailure *promos.ExportFilesFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	exportFailure = &promos.ExportFilesFailure{Files: new(promos.ExportFiles)}
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about user
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.UserId})
+		if err != nil {
+			return err
+		}
+
+		// Check user is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Get promos and their activations
+		allPromos, err := s.repo.ExportPromos(ctx, tx, in.Creator)
+		if err != nil {
+			return err
+		}
+		activations, err := s.repo.ExportActivations(ctx, tx, in.Creator)
+		if err != nil {
+			return err
+		}
+
+		// Encode files
+		if exportFailure.Files.Promos, err = files.EncodePromos(in.Format, allPromos); err != nil {
+			return err
+		}
+		if exportFailure.Files.Activations, err = files.EncodeActivations(in.Format, activations); err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.ExportFilesFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return exportFailure, nil
+}
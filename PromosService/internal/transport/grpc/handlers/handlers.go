@@ -59,24 +59,34 @@ func (s *ServicePromos) Create(ctx context.Context, in *promos.CreatePromo) (pro
 	return promoFailure, nil
 }
 
-func (s *ServicePromos) Delete(ctx context.Context, in *promos.PromoId) (*common.Response, error) {
+func (s *ServicePromos) Delete(ctx context.Context, in *promos.PromoUserId) (*common.Response, error) {
 	var codeError common.ErrorCode = common.ErrorCode_Forbidden
 
 	// Run in transaction
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
-		// Deleting history
-		if err := s.repo.DeleteActivatePromoFromHistory(ctx, tx, in); err != nil {
+		// Query to service users for get information about user
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.UserId})
+		if err != nil {
+			return err
+		}
+
+		// Check user is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
 			return err
 		}
 
-		// Deleting promo
+		// Deleting promo, history kept for accounting
 		if err := s.repo.DeletePromoById(ctx, tx, in); err != nil {
+			codeError = common.ErrorCode_PromoNotValid
 			return err
 		}
 
+		// Send transaction to service users
 		if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
-			Type: common.TransactionType_DeletePromoCode,
+			Sender: &users.UserTransaction{UserId: in.UserId},
+			Type:   common.TransactionType_DeletePromoCode,
 		}); err != nil {
 			return err
 		}
@@ -96,74 +106,103 @@ func (s *ServicePromos) Delete(ctx context.Context, in *promos.PromoId) (*common
 	return nil, nil
 }
 
-func (s *ServicePromos) Use(ctx context.Context, in *promos.PromoUserId) (*common.Response, error) {
+func (s *ServicePromos) DeleteByName(ctx context.Context, in *promos.PromoUserName) (*common.Response, error) {
 	var codeError common.ErrorCode = common.ErrorCode_Forbidden
 
 	// Run in transaction
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
-		// Query to db for get promo
-		promo, err := s.repo.GetPromoById(ctx, tx, &promos.PromoId{Id: in.PromoId})
+		// Query to service users for get information about user
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.UserId})
 		if err != nil {
 			return err
 		}
 
-		// Check promo is expired or not
-		if b, err := s.repo.PromoIsExpired(promo); err != nil || !b {
-			codeError = common.ErrorCode_PromoNotValid
+		// Check user is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
 			return err
 		}
 
-		// Check promo is in stock or not
-		if b, err := s.repo.PromoIsNotInStock(promo); err != nil || !b {
+		// Deleting promo, history kept for accounting
+		if err := s.repo.DeletePromoByName(ctx, tx, in); err != nil {
 			codeError = common.ErrorCode_PromoNotValid
 			return err
 		}
 
-		// Query to db for check activation promo from user
-		if b, err := s.repo.PromoIsAlreadyActivated(ctx, tx, in); err != nil || b {
-			codeError = common.ErrorCode_PromoAlreadyActivated
-			return err
-		}
-
-		// Query to db for decrement uses of promo
-		if err := s.repo.DecrementPromoUses(ctx, tx, &promos.PromoId{Id: in.PromoId}); err != nil {
-			return err
-		}
-
 		// Send transaction to service users
-		if _, err = s.users.SendTransaction(ctx, &users.TransactionRequest{
+		if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
 			Sender: &users.UserTransaction{UserId: in.UserId},
-			Type:   common.TransactionType_DecrementUsesPromo,
+			Type:   common.TransactionType_DeletePromoCode,
 		}); err != nil {
 			return err
 		}
 
-		// Query to db for adding promo activation in history
-		if err := s.repo.AddActivatePromoToHistory(ctx, tx, in); err != nil {
-			return err
-		}
+		return nil
 
-		// Send transaction to service users
-		if _, err = s.users.SendTransaction(ctx, &users.TransactionRequest{
-			Sender: &users.UserTransaction{UserId: in.UserId},
-			Type:   common.TransactionType_AddActivationPromoCodeToHistory,
-		}); err != nil {
+	}); errTx != nil {
+		return &common.Response{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return nil, nil
+}
+
+func (s *ServicePromos) Use(ctx context.Context, in *promos.PromoUserId) (rewardFailure *promos.RewardFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	rewardFailure = new(promos.RewardFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to db for get promo
+		promo, err := s.repo.GetPromoById(ctx, tx, &promos.PromoId{Id: in.PromoId})
+		if err != nil {
 			return err
 		}
 
-		// Send transaction to service users
-		if _, err = s.users.SendTransaction(ctx, &users.TransactionRequest{
-			Receiver: &users.UserTransaction{UserId: in.UserId, Amount: promo.Amount, Currency: promo.Currency},
-			Type:     common.TransactionType_ActivatePromoCode,
-		}); err != nil {
+		// Validate and activate promo
+		rewardFailure.Reward, codeError, err = s.activate(ctx, tx, promo, in.UserId)
+		return err
+
+	}); errTx != nil {
+		return &promos.RewardFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return rewardFailure, nil
+}
+
+func (s *ServicePromos) UseByName(ctx context.Context, in *promos.PromoUserName) (rewardFailure *promos.RewardFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	rewardFailure = new(promos.RewardFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to db for get promo, case and extra whitespaces of name ignored
+		promo, err := s.repo.GetPromoByNormalizedName(ctx, tx, &promos.PromoName{Name: in.Name})
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
 			return err
 		}
 
-		return nil
+		// Validate and activate promo
+		rewardFailure.Reward, codeError, err = s.activate(ctx, tx, promo, in.UserId)
+		return err
 
 	}); errTx != nil {
-		return &common.Response{
+		return &promos.RewardFailure{
 			Failure: &common.Failure{
 				Code: codeError,
 				Details: map[string]string{
@@ -172,7 +211,7 @@ func (s *ServicePromos) Use(ctx context.Context, in *promos.PromoUserId) (*commo
 			}}, errTx
 	}
 
-	return nil, nil
+	return rewardFailure, nil
 }
 
 func (s *ServicePromos) GetById(ctx context.Context, in *promos.PromoId) (promoFailure *promos.PromoFailure, err error) {
@@ -234,12 +273,202 @@ func (s *ServicePromos) GetByName(ctx context.Context, in *promos.PromoName) (pr
 	return promoFailure, nil
 }
 
+func (s *ServicePromos) List(ctx context.Context, in *promos.PromoFilter) (allPromosFailure *promos.AllPromosFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	allPromosFailure = new(promos.AllPromosFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get page of promos
+		allPromosFailure.Promos, err = s.repo.ListPromos(ctx, tx, in)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.AllPromosFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return allPromosFailure, nil
+}
+
+func (s *ServicePromos) GetStats(ctx context.Context, in *promos.PromoStatsIn) (statsFailure *promos.PromoStatsFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	statsFailure = new(promos.PromoStatsFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to db for get promo
+		promo, err := s.repo.GetPromoById(ctx, tx, &promos.PromoId{Id: in.PromoId})
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		// Get statistics of promo
+		statsFailure.Stats, err = s.repo.GetPromoStats(ctx, tx, promo, in.Bucket)
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.PromoStatsFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return statsFailure, nil
+}
+
+func (s *ServicePromos) GetActivations(ctx context.Context, in *promos.PromoActivationsIn) (activationsFailure *promos.PromoActivationsFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	activationsFailure = new(promos.PromoActivationsFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get page of activations
+		activationsFailure.Activations, err = s.repo.GetPromoActivations(ctx, tx, in)
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.PromoActivationsFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return activationsFailure, nil
+}
+
+func (s *ServicePromos) Pause(ctx context.Context, in *promos.PromoUserId) (*common.Response, error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about user
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.UserId})
+		if err != nil {
+			return err
+		}
+
+		// Check user is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Pause promo, history of activations kept
+		if err := s.repo.SetPromoActive(ctx, tx, &promos.PromoId{Id: in.PromoId}, false); err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &common.Response{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return nil, nil
+}
+
+func (s *ServicePromos) Resume(ctx context.Context, in *promos.PromoUserId) (*common.Response, error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for get information about user
+		user, err := s.users.GetUser(ctx, &users.Id{Id: in.UserId})
+		if err != nil {
+			return err
+		}
+
+		// Check user is owner or not
+		if b, err := s.repo.CreatorIsOwner(ctx, user); err != nil || !b {
+			codeError = common.ErrorCode_UserBadRole
+			return err
+		}
+
+		// Resume promo
+		if err := s.repo.SetPromoActive(ctx, tx, &promos.PromoId{Id: in.PromoId}, true); err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &common.Response{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return nil, nil
+}
+
 func (s *ServicePromos) AddTime(ctx context.Context, in *promos.AddTimeIn) (*common.Response, error) {
 	var codeError common.ErrorCode = common.ErrorCode_Forbidden
 
 	// Run in transaction
 	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
 
+		// Query to db for get promo
+		promo, err := s.repo.GetPromoById(ctx, tx, &promos.PromoId{Id: in.PromoId})
+		if err != nil {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		// Check promo is deleted or not
+		if b, err := s.repo.PromoIsNotDeleted(promo); err != nil || !b {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
+		// Check start of promo is before expiration after changing
+		if b, err := s.repo.PromoTimeIsValid(promo, in); err != nil || !b {
+			codeError = common.ErrorCode_PromoNotValid
+			return err
+		}
+
 		// Add time for promo
 		if err := s.repo.AddTime(ctx, tx, in); err != nil {
 			return err
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	e "errorspomka"
+	"protobuf/common"
+	"protobuf/promos"
+	"protobuf/users"
+	"utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *ServicePromos) GetReferralCode(ctx context.Context, in *users.Id) (promoFailure *promos.PromoFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	promoFailure = new(promos.PromoFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Query to service users for check user exists
+		if _, err := s.users.GetUser(ctx, in); err != nil {
+			codeError = common.ErrorCode_UserNotFound
+			return err
+		}
+
+		// Get referral code of user, code created on first request
+		promoFailure.PromoCode, err = s.repo.GetReferralCode(ctx, tx, in)
+		if errors.Is(err, e.ErrMissingPromoId) {
+			referral := s.cfg.Referral
+			promoFailure.PromoCode, err = s.repo.CreateReferralCode(ctx, tx, in, referral.Currency, referral.Reward, referral.OwnerReward)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.PromoFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return promoFailure, nil
+}
+
+func (s *ServicePromos) GetReferrals(ctx context.Context, in *users.Id) (referralsFailure *promos.ReferralsFailure, err error) {
+	var codeError common.ErrorCode = common.ErrorCode_Forbidden
+	referralsFailure = new(promos.ReferralsFailure)
+
+	// Run in transaction
+	if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+		// Get users, who joined with referral code of user
+		referralsFailure.Referrals, err = s.repo.GetUserReferrals(ctx, tx, in)
+		if err != nil {
+			return err
+		}
+
+		return nil
+
+	}); errTx != nil {
+		return &promos.ReferralsFailure{
+			Failure: &common.Failure{
+				Code: codeError,
+				Details: map[string]string{
+					"ERROR": errTx.Error(),
+				},
+			}}, errTx
+	}
+
+	return referralsFailure, nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"postgres"
+	"protobuf/common"
 	"protobuf/promos"
 	"protobuf/users"
 
@@ -10,6 +11,17 @@ import (
 	"google.golang.org/grpc"
 )
 
+type Config struct {
+	Referral Referral
+}
+
+// Rewards of referral code: new user gets reward, owner of code gets owner reward
+type Referral struct {
+	Currency    common.Currency
+	Reward      int64
+	OwnerReward int64
+}
+
 type UserService interface {
 	SendTransaction(ctx context.Context, in *users.TransactionRequest, opts ...grpc.CallOption) (*users.TransactionResponse, error)
 	GetUser(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*users.User, error)
@@ -18,30 +30,57 @@ type UserService interface {
 type ServicePromos struct {
 	repo  RepositoryPromos
 	db    *pgxpool.Pool
+	cfg   Config
 	users UserService
 	promos.UnimplementedPromosServer
 }
 
 type RepositoryPromos interface {
 	CreatePromo(ctx context.Context, db postgres.DB, in *promos.CreatePromo) (out *promos.PromoCode, err error)
-	DeletePromoById(ctx context.Context, db postgres.DB, in *promos.PromoId) (err error)
-	DeletePromoByName(ctx context.Context, db postgres.DB, in *promos.PromoName) (err error)
+	DeletePromoById(ctx context.Context, db postgres.DB, in *promos.PromoUserId) (err error)
+	DeletePromoByName(ctx context.Context, db postgres.DB, in *promos.PromoUserName) (err error)
 	GetPromoById(ctx context.Context, db postgres.DB, in *promos.PromoId) (out *promos.PromoCode, err error)
 	GetPromoByName(ctx context.Context, db postgres.DB, in *promos.PromoName) (out *promos.PromoCode, err error)
+	GetPromoByNormalizedName(ctx context.Context, db postgres.DB, in *promos.PromoName) (out *promos.PromoCode, err error)
+	ListPromos(ctx context.Context, db postgres.DB, in *promos.PromoFilter) (out *promos.AllPromos, err error)
 
+	PromoIsNotDeleted(in *promos.PromoCode) (b bool, err error)
 	PromoIsExpired(in *promos.PromoCode) (b bool, err error)
+	PromoIsStarted(in *promos.PromoCode) (b bool, err error)
+	PromoIsActive(in *promos.PromoCode) (b bool, err error)
+	UserIsInAudience(in *promos.PromoCode, user *users.User) (b bool, err error)
+	PromoTimeIsValid(promo *promos.PromoCode, in *promos.AddTimeIn) (b bool, err error)
 	PromoIsNotInStock(in *promos.PromoCode) (b bool, err error)
-	PromoIsAlreadyActivated(ctx context.Context, db postgres.DB, in *promos.PromoUserId) (b bool, err error)
+	PromoIsAlreadyActivated(ctx context.Context, db postgres.DB, promo *promos.PromoCode, userId int64) (b bool, err error)
 	CreatorIsOwner(ctx context.Context, user *users.User) (b bool, err error)
 
-	AddActivatePromoToHistory(ctx context.Context, db postgres.DB, in *promos.PromoUserId) (err error)
+	GetPromoStats(ctx context.Context, db postgres.DB, promo *promos.PromoCode, bucket promos.StatsBucket) (out *promos.PromoStats, err error)
+	GetPromoActivations(ctx context.Context, db postgres.DB, in *promos.PromoActivationsIn) (out *promos.PromoActivations, err error)
+	RollReward(ctx context.Context, db postgres.DB, promo *promos.PromoCode) (out *promos.Reward, err error)
+	AddActivatePromoToHistory(ctx context.Context, db postgres.DB, in *promos.PromoUserId, reward *promos.Reward) (err error)
 	DeleteActivatePromoFromHistory(ctx context.Context, db postgres.DB, in *promos.PromoId) (err error)
 	DecrementPromoUses(ctx context.Context, db postgres.DB, in *promos.PromoId) (err error)
 
+	SetPromoActive(ctx context.Context, db postgres.DB, in *promos.PromoId, active bool) (err error)
 	AddTime(ctx context.Context, db postgres.DB, in *promos.AddTimeIn) (err error)
 	AddUses(ctx context.Context, db postgres.DB, in *promos.AddUsesIn) (err error)
+
+	CreateCampaign(ctx context.Context, db postgres.DB, in *promos.CreateCampaignIn) (out *promos.Campaign, err error)
+	GetCampaignById(ctx context.Context, db postgres.DB, id int64) (out *promos.Campaign, err error)
+	GenerateCampaignCodes(ctx context.Context, db postgres.DB, campaign *promos.Campaign, in *promos.GenerateCodesIn) (codes []string, err error)
+	GetCampaignCodes(ctx context.Context, db postgres.DB, in *promos.ExportCodesIn) (out *promos.CampaignCodes, err error)
+	RedeemCampaignCode(ctx context.Context, db postgres.DB, id int64) (err error)
+
+	GetReferralCode(ctx context.Context, db postgres.DB, user *users.Id) (out *promos.PromoCode, err error)
+	CreateReferralCode(ctx context.Context, db postgres.DB, user *users.Id, currency common.Currency, amount, referrerAmount int64) (out *promos.PromoCode, err error)
+	UserCanBeReferred(ctx context.Context, db postgres.DB, in *promos.PromoCode, user *users.User) (b bool, err error)
+	AddReferral(ctx context.Context, db postgres.DB, in *promos.PromoCode, userId int64) (err error)
+	GetUserReferrals(ctx context.Context, db postgres.DB, user *users.Id) (out *promos.Referrals, err error)
+
+	ExportPromos(ctx context.Context, db postgres.DB, creator int64) (out []*promos.PromoCode, err error)
+	ExportActivations(ctx context.Context, db postgres.DB, creator int64) (out []*promos.PromoActivation, err error)
 }
 
-func NewServicePromos(repo RepositoryPromos, db *pgxpool.Pool, serviceUsers UserService) *ServicePromos {
-	return &ServicePromos{repo: repo, db: db, users: serviceUsers}
+func NewServicePromos(repo RepositoryPromos, db *pgxpool.Pool, cfg Config, serviceUsers UserService) *ServicePromos {
+	return &ServicePromos{repo: repo, db: db, cfg: cfg, users: serviceUsers}
 }
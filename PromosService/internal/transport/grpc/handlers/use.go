@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"protobuf/common"
+	"protobuf/promos"
+	"protobuf/users"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Validate promo and activate it by user, return granted reward. If promo can't be activated, return code of error for failure.
+func (s *ServicePromos) activate(ctx context.Context, tx pgx.Tx, promo *promos.PromoCode, userId int64) (*promos.Reward, common.ErrorCode, error) {
+	in := &promos.PromoUserId{UserId: userId, PromoId: promo.Id}
+
+	// Check promo is deleted or not, deleted promo can be found by id
+	if b, err := s.repo.PromoIsNotDeleted(promo); err != nil || !b {
+		return nil, common.ErrorCode_PromoNotValid, err
+	}
+
+	// Check promo is paused or not
+	if b, err := s.repo.PromoIsActive(promo); err != nil || !b {
+		return nil, common.ErrorCode_PromoNotValid, err
+	}
+
+	// Check promo is expired or not
+	if b, err := s.repo.PromoIsExpired(promo); err != nil || !b {
+		return nil, common.ErrorCode_PromoNotValid, err
+	}
+
+	// Check promo is started or not, failure says when promo starts
+	if b, err := s.repo.PromoIsStarted(promo); err != nil || !b {
+		return nil, common.ErrorCode_PromoNotValid, err
+	}
+
+	// Check promo is in stock or not
+	if b, err := s.repo.PromoIsNotInStock(promo); err != nil || !b {
+		return nil, common.ErrorCode_PromoNotValid, err
+	}
+
+	// Query to service users only for promo with audience or referral code
+	var user *users.User
+	if promo.Audience != nil || promo.Referrer != 0 {
+		var err error
+		if user, err = s.users.GetUser(ctx, &users.Id{Id: userId}); err != nil {
+			return nil, common.ErrorCode_UserNotFound, err
+		}
+	}
+
+	// Check user is in audience of promo
+	if promo.Audience != nil {
+		if b, err := s.repo.UserIsInAudience(promo, user); err != nil || !b {
+			return nil, common.ErrorCode_PromoNotValid, err
+		}
+	}
+
+	// Check user can join with referral code: new user, not owner of code and not referred before
+	if promo.Referrer != 0 {
+		if b, err := s.repo.UserCanBeReferred(ctx, tx, promo, user); err != nil || !b {
+			return nil, common.ErrorCode_PromoNotValid, err
+		}
+	}
+
+	// Query to db for decrement uses of promo, update locks promo till end of transaction
+	if err := s.repo.DecrementPromoUses(ctx, tx, &promos.PromoId{Id: in.PromoId}); err != nil {
+		return nil, common.ErrorCode_PromoNotValid, err
+	}
+
+	// Query to db for check activations promo from user, limit and cooldown of promo for one user.
+	// Promo is locked, so parallel activations by user are counted
+	if b, err := s.repo.PromoIsAlreadyActivated(ctx, tx, promo, userId); err != nil || b {
+		return nil, common.ErrorCode_PromoAlreadyActivated, err
+	}
+
+	// Generated code counts against uses of its campaign
+	if promo.CampaignId != 0 {
+		if err := s.repo.RedeemCampaignCode(ctx, tx, promo.CampaignId); err != nil {
+			return nil, common.ErrorCode_PromoNotValid, err
+		}
+	}
+
+	// Choose reward by reward table of promo, after decrement promo is locked
+	reward, err := s.repo.RollReward(ctx, tx, promo)
+	if err != nil {
+		return nil, common.ErrorCode_Forbidden, err
+	}
+
+	// Send transaction to service users
+	if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+		Sender: &users.UserTransaction{UserId: in.UserId},
+		Type:   common.TransactionType_DecrementUsesPromo,
+	}); err != nil {
+		return nil, common.ErrorCode_Forbidden, err
+	}
+
+	// Query to db for adding promo activation in history
+	if err := s.repo.AddActivatePromoToHistory(ctx, tx, in, reward); err != nil {
+		return nil, common.ErrorCode_Forbidden, err
+	}
+
+	// Send transaction to service users
+	if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+		Sender: &users.UserTransaction{UserId: in.UserId},
+		Type:   common.TransactionType_AddActivationPromoCodeToHistory,
+	}); err != nil {
+		return nil, common.ErrorCode_Forbidden, err
+	}
+
+	// Send transaction to service users
+	if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+		Receiver: &users.UserTransaction{UserId: in.UserId, Amount: reward.Amount, Currency: reward.Currency},
+		Type:     common.TransactionType_ActivatePromoCode,
+	}); err != nil {
+		return nil, common.ErrorCode_Forbidden, err
+	}
+
+	// Owner of referral code gets reward for new user
+	if promo.Referrer != 0 {
+		if err := s.repo.AddReferral(ctx, tx, promo, userId); err != nil {
+			return nil, common.ErrorCode_Forbidden, err
+		}
+
+		// Send transaction to service users
+		if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+			Receiver: &users.UserTransaction{UserId: promo.Referrer, Amount: promo.ReferrerAmount, Currency: promo.Currency},
+			Type:     common.TransactionType_ActivatePromoCode,
+		}); err != nil {
+			return nil, common.ErrorCode_Forbidden, err
+		}
+	}
+
+	return reward, common.ErrorCode_Forbidden, nil
+}
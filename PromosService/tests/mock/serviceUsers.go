@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"protobuf/users"
+	"time"
 	"utils"
 
 	e "errorspomka"
@@ -23,12 +24,14 @@ func NewMockServiceUsers(pool *pgxpool.Pool) *MockServiceUsers {
 }
 func (m *MockServiceUsers) GetUser(ctx context.Context, in *users.Id, opts ...grpc.CallOption) (*users.User, error) {
 	var user = new(users.User)
+	var createdAt time.Time
 	if errTx := utils.RunInTx(m.db, ctx, func(tx pgx.Tx) error {
 		q := `SELECT * FROM "Users" WHERE "Id"=$1`
-		if err := tx.QueryRow(ctx, q, in.Id).Scan(nil, nil, nil, nil, &user.Role, nil, nil, nil); err != nil {
+		if err := tx.QueryRow(ctx, q, in.Id).Scan(&user.Id, nil, nil, nil, &user.Role, nil, nil, &createdAt); err != nil {
 			return err
 		}
 
+		user.CreatedAt = timestamppb.New(createdAt)
 		return nil
 	}); errTx != nil {
 		return nil, errTx
@@ -9,8 +9,13 @@ import (
 	"promos/internal/repository"
 	service "promos/internal/transport/grpc/handlers"
 	"promos/tests/mock"
+	"protobuf/common"
 	"protobuf/promos"
+	"protobuf/users"
 	"server"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -23,9 +28,16 @@ import (
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Rewards of referral code in tests
+const (
+	referralReward      = 100
+	referralOwnerReward = 50
+)
+
 var srv *server.Server
 var client promos.PromosClient
 var serviceUsers *mock.MockServiceUsers
@@ -92,7 +104,8 @@ func TestMain(m *testing.M) {
 	repo = repository.NewRepository()
 
 	// Register promo service
-	service := service.NewServicePromos(repo, pool, serviceUsers)
+	service := service.NewServicePromos(repo, pool,
+		service.Config{Referral: service.Referral{Currency: common.Currency_Credits, Reward: referralReward, OwnerReward: referralOwnerReward}}, serviceUsers)
 	promos.RegisterPromosServer(grpcSrv, service)
 
 	// Run server
@@ -192,6 +205,17 @@ func TestCreateDelete(t *testing.T) {
 			},
 			err: true,
 		},
+		{
+			name: "starts after expiration",
+			in: &promos.CreatePromo{
+				Name:     uuid.NewString(),
+				Uses:     -1,
+				ExpAt:    timestamppb.New(time.Now().Add(time.Hour * 12)),
+				StartsAt: timestamppb.New(time.Now().Add(time.Hour * 13)),
+				Creator:  creator,
+			},
+			err: true,
+		},
 		{
 			name: "user dont have role creator",
 			in: &promos.CreatePromo{
@@ -223,6 +247,110 @@ func TestCreateDelete(t *testing.T) {
 
 }
 
+func TestSoftDelete(t *testing.T) {
+	var creator, user int64
+	var promoIds []int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from table Promos
+			if err := clearPromos(promoIds); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, user}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating user with role user
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := uuid.NewString()
+	create := func() int64 {
+		promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+			Name:    name,
+			Uses:    -1,
+			ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+			Creator: creator,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		promoIds = append(promoIds, promoFailure.PromoCode.Id)
+
+		return promoFailure.PromoCode.Id
+	}
+
+	promoId := create()
+	if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("user dont have role creator", func(t *testing.T) {
+		if _, err := client.Delete(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		if _, err := client.Delete(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: creator}); err != nil {
+			t.Fatal(err)
+		}
+
+		// Deleted promo found by id with audit, history kept
+		out, err := client.GetById(context.TODO(), &promos.PromoId{Id: promoId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out.PromoCode.DeletedAt == nil || out.PromoCode.DeletedBy != creator {
+			t.Fail()
+		}
+
+		activations, err := client.GetActivations(context.TODO(), &promos.PromoActivationsIn{PromoId: promoId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(activations.Activations.Activations) != 1 {
+			t.Fail()
+		}
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err == nil || !strings.Contains(err.Error(), e.ErrPromoDeleted.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("already deleted", func(t *testing.T) {
+		if _, err := client.Delete(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: creator}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("delete by name", func(t *testing.T) {
+		// Name of deleted promo can be used again
+		create()
+
+		if _, err := client.DeleteByName(context.TODO(), &promos.PromoUserName{Name: name, UserId: creator}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetByName(context.TODO(), &promos.PromoName{Name: name}); err == nil {
+			t.Fail()
+		}
+	})
+}
+
 func TestUse(t *testing.T) {
 	var userId int64
 	var promoId int64
@@ -335,19 +463,1254 @@ func TestUse(t *testing.T) {
 
 }
 
-func clearUsers(userIds []int64) error {
-	for _, userId := range userIds {
-		if err := serviceUsers.Delete(context.TODO(), userId); err != nil {
-			return err
+func TestStartsAt(t *testing.T) {
+	var userId int64
+	var promoId int64
+
+	t.Cleanup(
+		func() {
+
+			// Delete testing data from table Promos
+			if err := clearPromos([]int64{promoId}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{userId}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating creator user
+	userId, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+		Name:     uuid.NewString(),
+		Uses:     -1,
+		ExpAt:    timestamppb.New(time.Now().Add(time.Hour * 12)),
+		StartsAt: timestamppb.New(time.Now().Add(time.Hour)),
+		Creator:  userId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoId = promoFailure.PromoCode.Id
+
+	t.Run("not started", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: userId}); err == nil || !strings.Contains(err.Error(), e.ErrPromoNotStarted.Error()) {
+			t.Fail()
 		}
+	})
+
+	// Move start of promo to the past, only for tests
+	if _, err := client.AddTime(context.TODO(), &promos.AddTimeIn{PromoId: promoId, StartsAt: timestamppb.New(time.Now().Add(-time.Hour))}); err != nil {
+		t.Fatal(err)
 	}
 
-	return nil
+	t.Run("started", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: userId}); err != nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("start after expiration", func(t *testing.T) {
+		if _, err := client.AddTime(context.TODO(), &promos.AddTimeIn{PromoId: promoId, StartsAt: timestamppb.New(time.Now().Add(time.Hour * 24))}); err == nil || !strings.Contains(err.Error(), e.ErrStartsAt.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("expiration before start", func(t *testing.T) {
+		if _, err := client.AddTime(context.TODO(), &promos.AddTimeIn{PromoId: promoId, ExpAt: timestamppb.New(time.Now().Add(-time.Hour * 2))}); err == nil || !strings.Contains(err.Error(), e.ErrStartsAt.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("clear start", func(t *testing.T) {
+		if _, err := client.AddTime(context.TODO(), &promos.AddTimeIn{PromoId: promoId, ClearStartsAt: true}); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := client.GetById(context.TODO(), &promos.PromoId{Id: promoId})
+		if err != nil || out.PromoCode.StartsAt != nil {
+			t.Fail()
+		}
+	})
 }
 
-func clearPromos(promoIds []int64) error {
-	for _, promoId := range promoIds {
-		if _, err := client.Delete(context.TODO(), &promos.PromoId{Id: promoId}); err != nil {
+func TestPause(t *testing.T) {
+	var creator, user int64
+	var promoId int64
+
+	t.Cleanup(
+		func() {
+
+			// Delete testing data from table Promos
+			if err := clearPromos([]int64{promoId}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, user}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating user with role user
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+		Name:    uuid.NewString(),
+		Uses:    -1,
+		ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+		Creator: creator,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoId = promoFailure.PromoCode.Id
+
+	t.Run("user dont have role creator", func(t *testing.T) {
+		if _, err := client.Pause(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("paused", func(t *testing.T) {
+		if _, err := client.Pause(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: creator}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("resumed", func(t *testing.T) {
+		if _, err := client.Resume(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: creator}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err != nil {
+			t.Fail()
+		}
+	})
+}
+
+func TestUserLimit(t *testing.T) {
+	var creator, user int64
+	var promoIds []int64
+
+	t.Cleanup(
+		func() {
+
+			// Delete testing data from table Promos
+			if err := clearPromos(promoIds); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, user}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating user with role user
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("limit", func(t *testing.T) {
+		promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+			Name:      uuid.NewString(),
+			Uses:      -1,
+			ExpAt:     timestamppb.New(time.Now().Add(time.Hour * 12)),
+			Creator:   creator,
+			UserLimit: 2,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		promoIds = append(promoIds, promoFailure.PromoCode.Id)
+
+		for range 2 {
+			if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoFailure.PromoCode.Id, UserId: user}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoFailure.PromoCode.Id, UserId: user}); err == nil || !strings.Contains(err.Error(), e.ErrPromoUserLimit.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("cooldown", func(t *testing.T) {
+		promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+			Name:      uuid.NewString(),
+			Uses:      -1,
+			ExpAt:     timestamppb.New(time.Now().Add(time.Hour * 12)),
+			Creator:   creator,
+			UserLimit: -1,
+			Cooldown:  durationpb.New(time.Hour),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		promoIds = append(promoIds, promoFailure.PromoCode.Id)
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoFailure.PromoCode.Id, UserId: user}); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoFailure.PromoCode.Id, UserId: user}); err == nil || !strings.Contains(err.Error(), "next activation at") {
+			t.Fail()
+		}
+	})
+
+	t.Run("parallel activations", func(t *testing.T) {
+		promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+			Name:      uuid.NewString(),
+			Uses:      -1,
+			ExpAt:     timestamppb.New(time.Now().Add(time.Hour * 12)),
+			Creator:   creator,
+			UserLimit: 1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		promoIds = append(promoIds, promoFailure.PromoCode.Id)
+
+		// Only one of parallel activations by user passes limit
+		var wg sync.WaitGroup
+		var succeeded atomic.Int32
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoFailure.PromoCode.Id, UserId: user}); err == nil {
+					succeeded.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if succeeded.Load() != 1 {
+			t.Fail()
+		}
+	})
+}
+
+func TestParallelUses(t *testing.T) {
+	var creator int64
+	var usersIds []int64
+	var promoId int64
+
+	t.Cleanup(
+		func() {
+
+			// Delete testing data from table Promos
+			if err := clearPromos([]int64{promoId}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers(append(usersIds, creator)); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating users with role user
+	for range 5 {
+		user, err := serviceUsers.Create(context.TODO(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		usersIds = append(usersIds, user)
+	}
+
+	// Single-use promo
+	promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+		Name:    uuid.NewString(),
+		Uses:    1,
+		ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+		Creator: creator,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoId = promoFailure.PromoCode.Id
+
+	// Only one of parallel activations by different users gets the only use
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for _, user := range usersIds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: user}); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded.Load() != 1 {
+		t.Fail()
+	}
+
+	// Uses are over, not unlimited
+	out, err := client.GetById(context.TODO(), &promos.PromoId{Id: promoId})
+	if err != nil || out.PromoCode.Uses != 0 {
+		t.Fail()
+	}
+}
+
+func TestRewards(t *testing.T) {
+	var creator, first, second int64
+	var promoId int64
+
+	t.Cleanup(
+		func() {
+
+			// Delete testing data from table Promos
+			if err := clearPromos([]int64{promoId}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, first, second}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating users with role user
+	first, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("bad rewards", func(t *testing.T) {
+		if _, err := client.Create(context.TODO(), &promos.CreatePromo{
+			Name:    uuid.NewString(),
+			Uses:    -1,
+			ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+			Creator: creator,
+			Rewards: &promos.RewardTable{Options: []*promos.RewardOption{{Weight: 0, Amount: 10}}},
+		}); err == nil {
+			t.Fail()
+		}
+	})
+
+	// First activator gets reward of tier, others get the only option
+	promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+		Name:    uuid.NewString(),
+		Uses:    -1,
+		ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+		Creator: creator,
+		Rewards: &promos.RewardTable{
+			Tiers:   []*promos.RewardTier{{UpTo: 1, Currency: common.Currency_Stocks, Amount: 500}},
+			Options: []*promos.RewardOption{{Weight: 1, Currency: common.Currency_Credits, Amount: 10}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoId = promoFailure.PromoCode.Id
+
+	var tests = []struct {
+		name   string
+		userId int64
+		reward *promos.Reward
+	}{
+		{
+			name:   "tier",
+			userId: first,
+			reward: &promos.Reward{Currency: common.Currency_Stocks, Amount: 500},
+		},
+		{
+			name:   "option",
+			userId: second,
+			reward: &promos.Reward{Currency: common.Currency_Credits, Amount: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: tt.userId})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if out.Reward.Currency != tt.reward.Currency || out.Reward.Amount != tt.reward.Amount {
+				t.Fail()
+			}
+		})
+	}
+
+	t.Run("recorded", func(t *testing.T) {
+		out, err := client.GetActivations(context.TODO(), &promos.PromoActivationsIn{PromoId: promoId})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Newest first
+		if len(out.Activations.Activations) != 2 || out.Activations.Activations[1].Reward.Amount != 500 {
+			t.Fail()
+		}
+	})
+
+	t.Run("paid out by currencies", func(t *testing.T) {
+		out, err := client.GetStats(context.TODO(), &promos.PromoStatsIn{PromoId: promoId})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		paidOut := make(map[common.Currency]int64)
+		for _, paid := range out.Stats.PaidOut {
+			paidOut[paid.Currency] = paid.Amount
+		}
+		if len(paidOut) != 2 || paidOut[common.Currency_Stocks] != 500 || paidOut[common.Currency_Credits] != 10 {
+			t.Fail()
+		}
+	})
+}
+
+func TestUseByName(t *testing.T) {
+	var userId int64
+	var promoId int64
+
+	t.Cleanup(
+		func() {
+
+			// Delete testing data from table Promos
+			if err := clearPromos([]int64{promoId}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{userId}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating creator user
+	userId, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code := uuid.NewString()
+	promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+		Name:    "Summer Sale " + code,
+		Uses:    -1,
+		ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+		Creator: userId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoId = promoFailure.PromoCode.Id
+
+	var tests = []struct {
+		name string
+		in   *promos.PromoUserName
+		err  bool
+	}{
+		{
+			name: "other case and whitespaces",
+			in:   &promos.PromoUserName{UserId: userId, Name: "  summer \t SALE  " + strings.ToUpper(code) + " "},
+			err:  false,
+		},
+		{
+			name: "already activated",
+			in:   &promos.PromoUserName{UserId: userId, Name: "Summer Sale " + code},
+			err:  true,
+		},
+		{
+			name: "missing name",
+			in:   &promos.PromoUserName{UserId: userId, Name: uuid.NewString()},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.UseByName(context.TODO(), tt.in); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestCampaign(t *testing.T) {
+	var creator, user int64
+	var campaignId int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from tables Campaigns and Promos
+			if err := clearCampaign(campaignId); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, user}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating user with role user
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Campaign with one redemption for all codes
+	campaignFailure, err := client.CreateCampaign(context.TODO(), &promos.CreateCampaignIn{
+		Name:    uuid.NewString(),
+		Amount:  10,
+		Uses:    1,
+		ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+		Creator: creator,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	campaignId = campaignFailure.Campaign.Id
+
+	var tests = []struct {
+		name string
+		in   *promos.GenerateCodesIn
+		err  bool
+	}{
+		{
+			name: "common",
+			in:   &promos.GenerateCodesIn{CampaignId: campaignId, Creator: creator, Count: 5},
+			err:  false,
+		},
+		{
+			name: "custom alphabet",
+			in:   &promos.GenerateCodesIn{CampaignId: campaignId, Creator: creator, Count: 5, Alphabet: "abcdef", Length: 16},
+			err:  false,
+		},
+		{
+			name: "too short code",
+			in:   &promos.GenerateCodesIn{CampaignId: campaignId, Creator: creator, Count: 5, Length: 3},
+			err:  true,
+		},
+		{
+			name: "user dont have role creator",
+			in:   &promos.GenerateCodesIn{CampaignId: campaignId, Creator: user, Count: 5},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := client.GenerateCodes(context.TODO(), tt.in)
+			if (err != nil) != tt.err {
+				t.Fail()
+			}
+
+			if err == nil && len(out.Codes.Codes) != int(tt.in.Count) {
+				t.Fail()
+			}
+		})
+	}
+
+	// Export all codes
+	var codes []*promos.CampaignCode
+	var token string
+	for {
+		out, err := client.ExportCodes(context.TODO(), &promos.ExportCodesIn{CampaignId: campaignId, Creator: creator, Limit: 3, PageToken: token})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		codes = append(codes, out.Codes.Codes...)
+		token = out.Codes.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+	if len(codes) != 10 {
+		t.Fatal()
+	}
+
+	t.Run("export by not owner", func(t *testing.T) {
+		if _, err := client.ExportCodes(context.TODO(), &promos.ExportCodesIn{CampaignId: campaignId, Creator: user}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("codes not in list", func(t *testing.T) {
+		out, err := client.List(context.TODO(), &promos.PromoFilter{NamePrefix: codes[0].Code})
+		if err != nil || len(out.Promos.Promos) != 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("redeem code", func(t *testing.T) {
+		if _, err := client.UseByName(context.TODO(), &promos.PromoUserName{UserId: user, Name: codes[0].Code}); err != nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("campaign not in stock", func(t *testing.T) {
+		if _, err := client.UseByName(context.TODO(), &promos.PromoUserName{UserId: user, Name: codes[1].Code}); err == nil {
+			t.Fail()
+		}
+	})
+}
+
+func TestAudience(t *testing.T) {
+	var creator, user, other int64
+	var promoIds []int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from table Promos
+			if err := clearPromos(promoIds); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, user, other}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating users with role user
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		audience *promos.Audience
+		err      bool
+	}{
+		{
+			name:     "role",
+			audience: &promos.Audience{Roles: []users.Role{users.Role_Normal}},
+			err:      false,
+		},
+		{
+			name:     "other role",
+			audience: &promos.Audience{Roles: []users.Role{users.Role_Moderator}},
+			err:      true,
+		},
+		{
+			name:     "created after",
+			audience: &promos.Audience{CreatedAfter: timestamppb.New(time.Now().Add(-time.Hour))},
+			err:      false,
+		},
+		{
+			name:     "created before",
+			audience: &promos.Audience{CreatedBefore: timestamppb.New(time.Now().Add(-time.Hour))},
+			err:      true,
+		},
+		{
+			name:     "allowlist",
+			audience: &promos.Audience{Users: []int64{user}},
+			err:      false,
+		},
+		{
+			name:     "not in allowlist",
+			audience: &promos.Audience{Users: []int64{other}},
+			err:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := client.Create(context.TODO(), &promos.CreatePromo{
+				Name:     uuid.NewString(),
+				Uses:     -1,
+				ExpAt:    timestamppb.New(time.Now().Add(time.Hour * 12)),
+				Creator:  creator,
+				Audience: tt.audience,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			promoIds = append(promoIds, out.PromoCode.Id)
+
+			if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: out.PromoCode.Id, UserId: user}); (err != nil) != tt.err {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestReferral(t *testing.T) {
+	var owner, other, old, user, fresh int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from tables Referrals and Promos
+			if err := clearReferrals([]int64{owner, other, user, fresh}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{owner, other, old, user, fresh}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating users with role user
+	owner, err := serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// User created long before referral codes
+	old, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Exec(context.TODO(), `UPDATE "Users" SET "CreatedAt" = "CreatedAt" - INTERVAL '1 day' WHERE "Id" = $1`, old); err != nil {
+		t.Fatal(err)
+	}
+
+	ownerCode, err := client.GetReferralCode(context.TODO(), &users.Id{Id: owner})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCode, err := client.GetReferralCode(context.TODO(), &users.Id{Id: other})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// New user created after referral codes
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("same code", func(t *testing.T) {
+		out, err := client.GetReferralCode(context.TODO(), &users.Id{Id: owner})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		code := out.PromoCode
+		if code.Id != ownerCode.PromoCode.Id || code.Referrer != owner || code.Amount != referralReward || code.ReferrerAmount != referralOwnerReward {
+			t.Fail()
+		}
+	})
+
+	t.Run("own code", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: ownerCode.PromoCode.Id, UserId: owner}); err == nil || !strings.Contains(err.Error(), e.ErrReferralOwnCode.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("not new user", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: ownerCode.PromoCode.Id, UserId: old}); err == nil || !strings.Contains(err.Error(), e.ErrReferralNotNewUser.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("joined", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: ownerCode.PromoCode.Id, UserId: user}); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := client.GetReferrals(context.TODO(), &users.Id{Id: owner})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(out.Referrals.Referrals) != 1 || out.Referrals.Referrals[0].UserId != user {
+			t.Fail()
+		}
+	})
+
+	t.Run("already referred", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: otherCode.PromoCode.Id, UserId: user}); err == nil || !strings.Contains(err.Error(), e.ErrAlreadyReferred.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("mutual referral", func(t *testing.T) {
+		userCode, err := client.GetReferralCode(context.TODO(), &users.Id{Id: user})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: userCode.PromoCode.Id, UserId: owner}); err == nil || !strings.Contains(err.Error(), e.ErrReferralMutual.Error()) {
+			t.Fail()
+		}
+	})
+
+	t.Run("parallel requests of code", func(t *testing.T) {
+		fresh, err = serviceUsers.Create(context.TODO(), 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Every parallel request gets one code of user
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		ids := make(map[int64]struct{})
+		for range 5 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				out, err := client.GetReferralCode(context.TODO(), &users.Id{Id: fresh})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				ids[out.PromoCode.Id] = struct{}{}
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+
+		if len(ids) != 1 {
+			t.Fail()
+		}
+	})
+}
+
+func TestImportExport(t *testing.T) {
+	var creator, user int64
+	var promoIds []int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from table Promos
+			if err := clearPromos(promoIds); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator, user}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating user with role user
+	user, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name := uuid.NewString()
+	expAt := time.Now().Add(time.Hour * 12).UTC().Format(time.RFC3339)
+	data := fmt.Sprintf("name,amount,currency,uses,expAt,userLimit\n"+
+		"%[1]s,10,Credits,5,%[2]s,\n"+
+		"%[3]s,ten,Credits,5,%[2]s,\n"+
+		"%[1]s,10,Credits,5,%[2]s,2\n", name, expAt, uuid.NewString())
+
+	t.Run("user dont have role creator", func(t *testing.T) {
+		if _, err := client.Import(context.TODO(), &promos.ImportIn{UserId: user, Format: promos.FileFormat_CSV, Data: []byte(data)}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		out, err := client.Import(context.TODO(), &promos.ImportIn{UserId: creator, Format: promos.FileFormat_CSV, Data: []byte(data)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, promo := range out.Result.Promos {
+			promoIds = append(promoIds, promo.Id)
+		}
+
+		// Second row can't be parsed, third row has name of first row
+		errs := out.Result.Errors
+		if len(out.Result.Promos) != 1 || len(errs) != 2 || errs[0].Row != 2 || errs[1].Row != 3 {
+			t.Fail()
+		}
+	})
+
+	t.Run("bad file", func(t *testing.T) {
+		if _, err := client.Import(context.TODO(), &promos.ImportIn{UserId: creator, Format: promos.FileFormat_JSON, Data: []byte(data)}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("import rewards and audience", func(t *testing.T) {
+		data := fmt.Sprintf(`[{"name": %q, "amount": 10, "currency": "Credits", "uses": 5, "expAt": %q,
+			"rewards": {"tiers": [{"upTo": "2", "currency": "Stocks", "amount": "3"}]},
+			"audience": {"users": ["%d"]}}]`, uuid.NewString(), expAt, user)
+
+		out, err := client.Import(context.TODO(), &promos.ImportIn{UserId: creator, Format: promos.FileFormat_JSON, Data: []byte(data)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, promo := range out.Result.Promos {
+			promoIds = append(promoIds, promo.Id)
+		}
+
+		if len(out.Result.Promos) != 1 || len(out.Result.Promos[0].Rewards.GetTiers()) != 1 || len(out.Result.Promos[0].Audience.GetUsers()) != 1 {
+			t.Fail()
+		}
+	})
+
+	t.Run("export", func(t *testing.T) {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoIds[0], UserId: user}); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := client.Export(context.TODO(), &promos.ExportIn{UserId: creator, Format: promos.FileFormat_JSON, Creator: creator})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(string(out.Files.Promos), name) || !strings.Contains(string(out.Files.Activations), fmt.Sprintf(`"userId": %d`, user)) {
+			t.Fail()
+		}
+
+		// Rewards and audience are exported, so promo can be imported again
+		if !strings.Contains(string(out.Files.Promos), `"tiers"`) || !strings.Contains(string(out.Files.Promos), `"users": [`) {
+			t.Fail()
+		}
+	})
+
+	t.Run("export deleted", func(t *testing.T) {
+		if _, err := client.Delete(context.TODO(), &promos.PromoUserId{PromoId: promoIds[0], UserId: creator}); err != nil {
+			t.Fatal(err)
+		}
+
+		out, err := client.Export(context.TODO(), &promos.ExportIn{UserId: creator, Format: promos.FileFormat_JSON, Creator: creator})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Deleted promo is marked, its activations are kept
+		if !strings.Contains(string(out.Files.Promos), name) || !strings.Contains(string(out.Files.Promos), fmt.Sprintf(`"deletedBy": %d`, creator)) ||
+			!strings.Contains(string(out.Files.Activations), fmt.Sprintf(`"userId": %d`, user)) {
+			t.Fail()
+		}
+	})
+}
+
+func TestList(t *testing.T) {
+	var creator int64
+	var promoIds []int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from table Promos
+			if err := clearPromos(promoIds); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers([]int64{creator}); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating user with role creator
+	creator, err := serviceUsers.Create(context.TODO(), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Creating 3 active promos and 1 expired promo with same prefix of name
+	prefix := uuid.NewString()
+	for i, expAt := range []time.Time{
+		time.Now().Add(time.Hour),
+		time.Now().Add(time.Hour * 2),
+		time.Now().Add(time.Hour * 3),
+		time.Now().Add(-time.Hour),
+	} {
+		out, err := client.Create(context.TODO(), &promos.CreatePromo{
+			Name:    fmt.Sprintf("%s-%d", prefix, i),
+			Uses:    -1,
+			ExpAt:   timestamppb.New(expAt),
+			Creator: creator,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		promoIds = append(promoIds, out.PromoCode.Id)
+	}
+
+	// Walk all pages
+	var got []*promos.PromoInfo
+	var token string
+	for {
+		out, err := client.List(context.TODO(), &promos.PromoFilter{NamePrefix: prefix, Limit: 3, PageToken: token, Order: promos.PromoOrder_ByExpAt})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got = append(got, out.Promos.Promos...)
+		token = out.Promos.NextPageToken
+		if token == "" {
+			break
+		}
+	}
+
+	if len(got) != 4 || got[0].PromoCode.Id != promoIds[3] || got[0].ExpiresIn.AsDuration() != 0 || got[1].ExpiresIn.AsDuration() <= 0 {
+		t.Fail()
+	}
+
+	var tests = []struct {
+		name   string
+		filter *promos.PromoFilter
+		count  int
+		err    bool
+	}{
+		{
+			name:   "active",
+			filter: &promos.PromoFilter{NamePrefix: prefix, State: promos.PromoState_Active},
+			count:  3,
+			err:    false,
+		},
+		{
+			name:   "expired",
+			filter: &promos.PromoFilter{NamePrefix: prefix, State: promos.PromoState_Expired},
+			count:  1,
+			err:    false,
+		},
+		{
+			name:   "exhausted",
+			filter: &promos.PromoFilter{NamePrefix: prefix, State: promos.PromoState_Exhausted},
+			count:  0,
+			err:    false,
+		},
+		{
+			name:   "creator",
+			filter: &promos.PromoFilter{NamePrefix: prefix, Creator: &creator},
+			count:  4,
+			err:    false,
+		},
+		{
+			name:   "bad page token",
+			filter: &promos.PromoFilter{NamePrefix: prefix, PageToken: "bad token"},
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := client.List(context.TODO(), tt.filter)
+			if (err != nil) != tt.err {
+				t.Fail()
+			}
+
+			if err == nil && len(out.Promos.Promos) != tt.count {
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	var userIds []int64
+	var promoId int64
+
+	t.Cleanup(
+		func() {
+			// Delete testing data from table Promos
+			if err := clearPromos([]int64{promoId}); err != nil {
+				t.Fatal(err)
+			}
+
+			// Delete testing data from table Users
+			if err := clearUsers(userIds); err != nil {
+				t.Fatal(err)
+			}
+		},
+	)
+
+	// Creating creator user and 3 users
+	for _, role := range []int{3, 1, 1, 1} {
+		userId, err := serviceUsers.Create(context.TODO(), role)
+		if err != nil {
+			t.Fatal(err)
+		}
+		userIds = append(userIds, userId)
+	}
+
+	promoFailure, err := client.Create(context.TODO(), &promos.CreatePromo{
+		Name:    uuid.NewString(),
+		Amount:  10,
+		Uses:    -1,
+		ExpAt:   timestamppb.New(time.Now().Add(time.Hour * 12)),
+		Creator: userIds[0],
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	promoId = promoFailure.PromoCode.Id
+
+	// Every user uses promo
+	for _, userId := range userIds[1:] {
+		if _, err := client.Use(context.TODO(), &promos.PromoUserId{PromoId: promoId, UserId: userId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("stats", func(t *testing.T) {
+		out, err := client.GetStats(context.TODO(), &promos.PromoStatsIn{PromoId: promoId, Bucket: promos.StatsBucket_Hour})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stats := out.Stats
+		if stats.Activations != 3 || stats.UniqueUsers != 3 || len(stats.PaidOut) != 1 || stats.PaidOut[0].Amount != 30 || len(stats.Buckets) == 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("stats of missing promo", func(t *testing.T) {
+		if _, err := client.GetStats(context.TODO(), &promos.PromoStatsIn{PromoId: -1}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("activations", func(t *testing.T) {
+		var got []*promos.PromoActivation
+		var token string
+		for {
+			out, err := client.GetActivations(context.TODO(), &promos.PromoActivationsIn{PromoId: promoId, Limit: 2, PageToken: token})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got = append(got, out.Activations.Activations...)
+			token = out.Activations.NextPageToken
+			if token == "" {
+				break
+			}
+		}
+
+		if len(got) != 3 {
+			t.Fail()
+		}
+	})
+}
+
+func clearUsers(userIds []int64) error {
+	for _, userId := range userIds {
+		if err := serviceUsers.Delete(context.TODO(), userId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete promos with their history, Delete of service only marks promo as deleted
+func clearPromos(promoIds []int64) error {
+	for _, q := range []string{
+		`DELETE FROM "UserToPromo" WHERE "PromoId" = ANY($1)`,
+		`DELETE FROM "Promos" WHERE "Id" = ANY($1)`,
+	} {
+		if _, err := pool.Exec(context.TODO(), q, promoIds); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func clearCampaign(campaignId int64) error {
+	for _, q := range []string{
+		`DELETE FROM "UserToPromo" WHERE "PromoId" IN (SELECT "Id" FROM "Promos" WHERE "CampaignId" = $1)`,
+		`DELETE FROM "Promos" WHERE "CampaignId" = $1`,
+		`DELETE FROM "Campaigns" WHERE "Id" = $1`,
+	} {
+		if _, err := pool.Exec(context.TODO(), q, campaignId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func clearReferrals(referrerIds []int64) error {
+	for _, q := range []string{
+		`DELETE FROM "Referrals" WHERE "ReferrerId" = ANY($1)`,
+		`DELETE FROM "UserToPromo" WHERE "PromoId" IN (SELECT "Id" FROM "Promos" WHERE "ReferrerId" = ANY($1))`,
+		`DELETE FROM "Promos" WHERE "ReferrerId" = ANY($1)`,
+	} {
+		if _, err := pool.Exec(context.TODO(), q, referrerIds); err != nil {
 			return err
 		}
 	}
@@ -8,6 +8,7 @@ import (
 	"migrations"
 	"protobuf/warns"
 	"server"
+	"time"
 	"warns/internal/repository"
 	service "warns/internal/transport/grpc/handlers"
 
@@ -57,12 +58,34 @@ func Run() {
 	service := service.NewServiceWarns(repo, pool, service.Config{WarnsBeforeBan: cfg.Storage.WarnsBeforeBan}, clientServices)
 	warns.RegisterWarnsServer(grpcSrv, service)
 
+	// Run deactivation of expired bans in background
+	sweepCtx, stopSweep := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(time.Duration(cfg.Storage.WarnsSweepIntervalS) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-sweepCtx.Done():
+				return
+			case <-ticker.C:
+				if err := service.DeactivateExpiredBans(sweepCtx); err != nil {
+					logger.WithField("ERROR", err).Error("DEACTIVATE EXPIRED BANS")
+				}
+			}
+		}
+	}()
+	logger.WithField("MSG", fmt.Sprintf("Running deactivation of expired bans every %ds", cfg.Storage.WarnsSweepIntervalS)).Debug("SETUP APP")
+
 	// Run server
 	logger.WithField("MSG", fmt.Sprintf("Running server on %s:%s", cfg.Server.Network, cfg.Server.Port)).Debug("SETUP APP")
 	server := server.NewServer(grpcSrv)
 
 	// defer all stoping
 	defer func() {
+		stopSweep()
+		logger.WithField("MSG", "Stoping deactivation of expired bans").Debug("CLOSING APP")
+
 		pool.Close()
 		logger.WithField("MSG", fmt.Sprintf("Closing connect to postgres://%s:%s@%s:%s/%s",
 			cfg.DB.User, "<PASSWORD>", cfg.DB.Host, cfg.DB.Port, cfg.DB.Database)).Debug("CLOSING APP")
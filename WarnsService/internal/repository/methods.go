@@ -9,6 +9,7 @@ import (
 	"protobuf/warns"
 	"time"
 
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -34,16 +35,41 @@ func (r *Repository) CreateBan(ctx context.Context, db postgres.DB, in *warns.Mo
 	ban = new(warns.Ban)
 	var issuedAt = new(time.Time)
 
-	q := `INSERT INTO "Bans" ("UserId", "ModeratorId", "Reason") 
-		  VALUES ($1, $2, $3)
-		  RETURNING "Id", "UserId", "ModeratorId", "Reason", "IssuedAt", "IsActive"`
+	// Ban with expiration by time or by duration, permanent if both empty
+	var expAt *time.Time
+	now := time.Now().UTC()
+	switch {
+	case in.ExpiresAt != nil:
+		t := in.ExpiresAt.AsTime()
+		expAt = &t
+	case in.Duration != nil:
+		t := now.Add(in.Duration.AsDuration())
+		expAt = &t
+	}
+
+	var expAtString *string
+	if expAt != nil {
+		if !expAt.After(now) {
+			return nil, e.ErrBanExpiresAt
+		}
 
-	err = db.QueryRow(ctx, q, in.UserId, in.ModerId, in.Reason).Scan(&ban.Id, &ban.UserId, &ban.ModerId, &ban.Reason, &issuedAt, &ban.IsActive)
+		t := expAt.Format("2006-01-02 15:04:05")
+		expAtString = &t
+	}
+
+	q := `INSERT INTO "Bans" ("UserId", "ModeratorId", "Reason", "ExpAt") 
+		  VALUES ($1, $2, $3, $4)
+		  RETURNING "Id", "UserId", "ModeratorId", "Reason", "IssuedAt", "IsActive", "ExpAt"`
+
+	err = db.QueryRow(ctx, q, in.UserId, in.ModerId, in.Reason, expAtString).Scan(&ban.Id, &ban.UserId, &ban.ModerId, &ban.Reason, &issuedAt, &ban.IsActive, &expAt)
 	if err != nil {
 		return nil, errors.Join(e.ErrExecQuery, err)
 	}
 
 	ban.IssuedAt = timestamppb.New(*issuedAt)
+	if expAt != nil {
+		ban.ExpiresAt = timestamppb.New(*expAt)
+	}
 
 	return ban, nil
 }
@@ -87,12 +113,16 @@ func (r *Repository) GetBans(ctx context.Context, db postgres.DB, in *users.Id)
 	for rows.Next() {
 		var ban = new(warns.Ban)
 		var issuedAt = new(time.Time)
+		var expAt *time.Time
 
-		if err := rows.Scan(&ban.Id, &ban.UserId, &ban.ModerId, &ban.Reason, &issuedAt, &ban.IsActive); err != nil {
+		if err := rows.Scan(&ban.Id, &ban.UserId, &ban.ModerId, &ban.Reason, &issuedAt, &ban.IsActive, &expAt); err != nil {
 			return nil, errors.Join(e.ErrIncorrectData, err)
 		}
 
 		ban.IssuedAt = timestamppb.New(*issuedAt)
+		if expAt != nil {
+			ban.ExpiresAt = timestamppb.New(*expAt)
+		}
 
 		allbans.Bans = append(allbans.Bans, ban)
 	}
@@ -152,6 +182,19 @@ func (r *Repository) MakeBanInActive(ctx context.Context, db postgres.DB, in *us
 	return nil
 }
 
+// Make only this ban inactive, if it is still active and expired. Returns false, if ban wasn't changed.
+func (r *Repository) MakeExpiredBanInActive(ctx context.Context, db postgres.DB, in *warns.Ban) (bool, error) {
+	q := `UPDATE "Bans" SET "IsActive"=FALSE
+	      WHERE "Id"=$1 AND "IsActive"=TRUE AND "ExpAt" IS NOT NULL AND "ExpAt" <= $2`
+
+	tag, err := db.Exec(ctx, q, in.Id, time.Now().UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return false, errors.Join(e.ErrExecQuery, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
 func (r *Repository) GetCountOfActiveWarns(ctx context.Context, db postgres.DB, in *users.Id) (*warns.CountOfActiveWarns, error) {
 	var cnt = new(int)
 
@@ -193,18 +236,63 @@ func (r *Repository) GetActiveBan(ctx context.Context, db postgres.DB, in *users
 	q := `SELECT * FROM "Bans"
 	      WHERE "UserId"=$1 AND "IsActive"=TRUE`
 
+	return r.getBan(ctx, db, q, in)
+}
+
+// Get active ban and lock it until end of transaction, so it can't be changed meanwhile
+func (r *Repository) LockActiveBan(ctx context.Context, db postgres.DB, in *users.Id) (ban *warns.Ban, err error) {
+	q := `SELECT * FROM "Bans"
+	      WHERE "UserId"=$1 AND "IsActive"=TRUE
+	      FOR UPDATE`
+
+	return r.getBan(ctx, db, q, in)
+}
+
+func (r *Repository) getBan(ctx context.Context, db postgres.DB, q string, in *users.Id) (ban *warns.Ban, err error) {
 	var issuedAt = new(time.Time)
+	var expAt *time.Time
 	ban = new(warns.Ban)
 
-	if err := db.QueryRow(ctx, q, in.Id).Scan(&ban.Id, &ban.UserId, &ban.ModerId, &ban.Reason, &issuedAt, &ban.IsActive); err != nil {
+	if err := db.QueryRow(ctx, q, in.Id).Scan(&ban.Id, &ban.UserId, &ban.ModerId, &ban.Reason, &issuedAt, &ban.IsActive, &expAt); err != nil {
 		return nil, errors.Join(e.ErrExecQuery, err)
 	}
 
 	ban.IssuedAt = timestamppb.New(*issuedAt)
 
+	// Remaining time of ban with expiration, zero if ban expired, but not made inactive yet
+	if expAt != nil {
+		ban.ExpiresAt = timestamppb.New(*expAt)
+		ban.Remaining = durationpb.New(max(time.Until(*expAt), 0))
+	}
+
 	return ban, nil
 }
 
+// Get users with expired active bans
+func (r *Repository) GetExpiredBans(ctx context.Context, db postgres.DB) ([]*users.Id, error) {
+	var ids []*users.Id
+
+	q := `SELECT "UserId" FROM "Bans"
+	      WHERE "IsActive"=TRUE AND "ExpAt" IS NOT NULL AND "ExpAt" <= $1`
+
+	rows, err := db.Query(ctx, q, time.Now().UTC().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		return nil, errors.Join(e.ErrExecQuery, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id = new(users.Id)
+		if err := rows.Scan(&id.Id); err != nil {
+			return nil, errors.Join(e.ErrIncorrectData, err)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
 func (r *Repository) MakeLastWarnInActive(ctx context.Context, db postgres.DB, in *users.Id) (err error) {
 	q := `DELETE FROM "Warns"
 		  WHERE "UserId" = $1 AND "Id" = (SELECT "Id" FROM "Warns" ORDER BY "IssuedAt" DESC LIMIT 1);`
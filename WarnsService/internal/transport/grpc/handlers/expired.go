@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"protobuf/common"
+	"protobuf/users"
+	"time"
+	"utils"
+
+	e "errorspomka"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Make expired bans inactive and set role user back
+func (s *ServiceWarns) DeactivateExpiredBans(ctx context.Context) error {
+	var errs []error
+
+	// Get users with expired bans
+	ids, err := s.repo.GetExpiredBans(ctx, s.db)
+	if err != nil {
+		return err
+	}
+
+	// Deactivate every ban in own transaction, so one failed ban doesn't block others
+	for _, id := range ids {
+		if errTx := utils.RunInTx(s.db, ctx, func(tx pgx.Tx) error {
+
+			// Lock ban and check it is still active and expired, moderator can unban or ban again meanwhile
+			ban, err := s.repo.LockActiveBan(ctx, tx, id)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if ban.ExpiresAt == nil || ban.ExpiresAt.AsTime().After(time.Now()) {
+				return nil
+			}
+
+			// Remove only this ban, other bans of user are not touched
+			if ok, err := s.repo.MakeExpiredBanInActive(ctx, tx, ban); err != nil {
+				return errors.Join(e.ErrMakeBansInActive, err)
+			} else if !ok {
+				return nil
+			}
+
+			// Send transaction to service users
+			if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+				Sender:   &users.UserTransaction{UserId: ban.ModerId},
+				Receiver: &users.UserTransaction{UserId: id.Id},
+				Type:     common.TransactionType_User,
+			}); err != nil {
+				return errors.Join(e.ErrSendTransaction, err)
+			}
+
+			// Send transaction to service users
+			if _, err := s.users.SendTransaction(ctx, &users.TransactionRequest{
+				Sender:   &users.UserTransaction{UserId: ban.ModerId},
+				Receiver: &users.UserTransaction{UserId: id.Id},
+				Type:     common.TransactionType_InActiveBan,
+			}); err != nil {
+				return errors.Join(e.ErrSendTransaction, err)
+			}
+
+			return nil
+
+		}); errTx != nil {
+			errs = append(errs, errTx)
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -35,10 +35,13 @@ type RepositoryWarns interface {
 	GetBans(ctx context.Context, db postgres.DB, in *users.Id) (warn *warns.AllBans, err error)
 	GetActiveWarns(ctx context.Context, db postgres.DB, in *users.Id) (warn *warns.AllWarns, err error)
 	GetActiveBan(ctx context.Context, db postgres.DB, in *users.Id) (warn *warns.Ban, err error)
+	LockActiveBan(ctx context.Context, db postgres.DB, in *users.Id) (warn *warns.Ban, err error)
+	GetExpiredBans(ctx context.Context, db postgres.DB) (out []*users.Id, err error)
 	IsUserModerator(ctx context.Context, in *users.User) (b bool, err error)
 	MakeWarnsInActive(ctx context.Context, db postgres.DB, in *users.Id) (err error)
 	MakeLastWarnInActive(ctx context.Context, db postgres.DB, in *users.Id) (err error)
 	MakeBanInActive(ctx context.Context, db postgres.DB, in *users.Id) (err error)
+	MakeExpiredBanInActive(ctx context.Context, db postgres.DB, in *warns.Ban) (b bool, err error)
 	GetCountOfActiveWarns(ctx context.Context, db postgres.DB, in *users.Id) (*warns.CountOfActiveWarns, error)
 	IsAlreadyBanned(ctx context.Context, db postgres.DB, in *users.Id) (b bool, err error)
 }
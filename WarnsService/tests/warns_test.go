@@ -9,6 +9,7 @@ import (
 	"protobuf/warns"
 	"server"
 	"testing"
+	"time"
 	"warns/internal/repository"
 	service "warns/internal/transport/grpc/handlers"
 	"warns/tests/mock"
@@ -23,6 +24,8 @@ import (
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var srv *server.Server
@@ -30,6 +33,7 @@ var client warns.WarnsClient
 var serviceUsers *mock.MockServiceUsers
 var dockerpostgres *mock.DockerPool
 var repo *repository.Repository
+var serviceWarns *service.ServiceWarns
 var pool *pgxpool.Pool
 var cfg config.Config
 
@@ -93,8 +97,8 @@ func TestMain(m *testing.M) {
 	repo = repository.NewRepository()
 
 	// Register promo service
-	service := service.NewServiceWarns(repo, pool, service.Config{WarnsBeforeBan: cfg.Storage.WarnsBeforeBan}, serviceUsers)
-	warns.RegisterWarnsServer(grpcSrv, service)
+	serviceWarns = service.NewServiceWarns(repo, pool, service.Config{WarnsBeforeBan: cfg.Storage.WarnsBeforeBan}, serviceUsers)
+	warns.RegisterWarnsServer(grpcSrv, serviceWarns)
 
 	// Run server
 	srv = server.NewServer(grpcSrv)
@@ -198,6 +202,80 @@ func TestAll(t *testing.T) {
 	}
 }
 
+func TestTimedBan(t *testing.T) {
+	var moderId, userId int64
+
+	t.Cleanup(func() {
+		userIds := []int64{moderId, userId}
+
+		if err := clearWarnsBans(userIds); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := clearUsers(userIds); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// Create moderator
+	moderId, err := serviceUsers.Create(context.TODO(), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create bad boy
+	userId, err = serviceUsers.Create(context.TODO(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("expiration before now", func(t *testing.T) {
+		if _, err := client.Ban(context.TODO(), &warns.ModerUserReason{
+			ModerId:   moderId,
+			UserId:    userId,
+			ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour)),
+		}); err == nil {
+			t.Fail()
+		}
+	})
+
+	t.Run("remaining time", func(t *testing.T) {
+		if _, err := client.Ban(context.TODO(), &warns.ModerUserReason{
+			ModerId:  moderId,
+			UserId:   userId,
+			Duration: durationpb.New(time.Hour),
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		banFailure, err := client.GetActiveBan(context.TODO(), &users.Id{Id: userId})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		remaining := banFailure.Ban.Remaining.AsDuration()
+		if banFailure.Ban.ExpiresAt == nil || remaining <= 0 || remaining > time.Hour {
+			t.Fail()
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		// Expire ban, only for tests
+		if _, err := pool.Exec(context.TODO(), `UPDATE "Bans" SET "ExpAt" = $1 WHERE "UserId" = $2`,
+			time.Now().UTC().Add(-time.Minute).Format("2006-01-02 15:04:05"), userId); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := serviceWarns.DeactivateExpiredBans(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := client.GetActiveBan(context.TODO(), &users.Id{Id: userId}); err == nil {
+			t.Fail()
+		}
+	})
+}
+
 func clearWarnsBans(userIds []int64) error {
 	for _, userId := range userIds {
 		if err := repo.DeleteHistoryWarns(context.TODO(), pool, &users.Id{Id: userId}); err != nil {